@@ -0,0 +1,720 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	boardSize  = 15
+	bingoTiles = 7
+	bingoBonus = 50
+)
+
+type premium uint8
+
+const (
+	premiumNone premium = iota
+	premiumDoubleLetter
+	premiumTripleLetter
+	premiumDoubleWord
+	premiumTripleWord
+)
+
+type direction uint8
+
+const (
+	across direction = iota
+	down
+)
+
+// standardLayout describes the premium squares of the
+// standard board, using the following notation:
+// T: triple word, D: double word, t: triple letter,
+// d: double letter.
+var standardLayout = [boardSize]string{
+	"T..d...T...d..T",
+	".D...t...t...D.",
+	"..D...d.d...D..",
+	"d..D...d...D..d",
+	"....D.....D....",
+	".t...t...t...t.",
+	"..d...d.d...d..",
+	"T..d...D...d..T",
+	"..d...d.d...d..",
+	".t...t...t...t.",
+	"....D.....D....",
+	"d..D...d...D..d",
+	"..D...d.d...D..",
+	".D...t...t...D.",
+	"T..d...T...d..T",
+}
+
+// cell represents a letter placed on a square of the board.
+// A blank tile is assigned the letter it represents, but it
+// scores no points.
+type cell struct {
+	L      string
+	points uint
+	blank  bool
+}
+
+type square struct {
+	cell
+	bonus premium
+}
+
+// board represents a Scrabble board with its premium
+// squares and the letters placed on it.
+type board struct {
+	squares [boardSize][boardSize]square
+	lex     wordSet
+	distrib distribution
+	count   int
+}
+
+// move represents a play on the board. The cells are the
+// letters of the main word formed, in reading order, from
+// the starting position. Only the cells flagged as fresh
+// are new tiles placed from the rack.
+type move struct {
+	row   int
+	col   int
+	dir   direction
+	cells []cell
+	fresh []bool
+	score int
+}
+
+// crossCheck represents the constraints of the word formed
+// perpendicularly to the main word on an empty square.
+type crossCheck struct {
+	neighbors bool
+	allowed   map[string]bool
+	score     int
+}
+
+func (p premium) multipliers() (letter, word int) {
+	switch p {
+	case premiumDoubleLetter:
+		return 2, 1
+	case premiumTripleLetter:
+		return 3, 1
+	case premiumDoubleWord:
+		return 1, 2
+	case premiumTripleWord:
+		return 1, 3
+	default:
+		return 1, 1
+	}
+}
+
+func (d direction) String() string {
+	switch d {
+	case across:
+		return "across"
+	case down:
+		return "down"
+	default:
+		return "<unknown>"
+	}
+}
+
+func (s square) isEmpty() bool {
+	return s.L == ""
+}
+
+func (cc crossCheck) allows(l string) bool {
+	return !cc.neighbors || cc.allowed[l]
+}
+
+// newBoard returns an empty board with the standard
+// premium squares layout.
+func newBoard(d distribution, lex wordSet) *board {
+	b := &board{
+		lex:     lex,
+		distrib: d,
+	}
+	for i, row := range standardLayout {
+		for j, c := range row {
+			var p premium
+			switch c {
+			case 'T':
+				p = premiumTripleWord
+			case 'D':
+				p = premiumDoubleWord
+			case 't':
+				p = premiumTripleLetter
+			case 'd':
+				p = premiumDoubleLetter
+			}
+			b.squares[i][j].bonus = p
+		}
+	}
+	return b
+}
+
+// isEmpty returns whether no letter has been placed yet.
+func (b *board) isEmpty() bool {
+	return b.count == 0
+}
+
+// at returns the square located at the given position of
+// a line, where a line is a row for across moves and a
+// column for down moves.
+func (b *board) at(line, pos int, dir direction) *square {
+	if dir == across {
+		return &b.squares[line][pos]
+	}
+	return &b.squares[pos][line]
+}
+
+// filled returns whether the square at the given
+// coordinates exists and holds a letter.
+func (b *board) filled(row, col int) bool {
+	if row < 0 || row >= boardSize || col < 0 || col >= boardSize {
+		return false
+	}
+	return !b.squares[row][col].isEmpty()
+}
+
+// place puts the fresh letters of the move on the board.
+func (b *board) place(m move) {
+	for i, c := range m.cells {
+		if !m.fresh[i] {
+			continue
+		}
+		row, col := m.coords(i)
+		b.squares[row][col].cell = c
+		b.count++
+	}
+}
+
+// moves returns all the valid moves that can be played
+// with the tiles of the rack, sorted by descending score.
+func (b *board) moves(r rack) []move {
+	moves := b.findMoves(r, false)
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].before(moves[j])
+	})
+	return moves
+}
+
+// findMoves returns all the valid moves that can be
+// played with the tiles of the rack, unsorted, or only
+// the highest-scoring one if top is set.
+func (b *board) findMoves(r rack, top bool) []move {
+	if b.lex == nil {
+		return nil
+	}
+	g := b.generator(r, top)
+
+	for _, dir := range []direction{across, down} {
+		g.generate(dir)
+	}
+	return g.moves
+}
+
+// lineMoves returns the valid moves that can be played with
+// the tiles of the rack on a single row or column, sorted by
+// descending score.
+func (b *board) lineMoves(r rack, dir direction, line int) []move {
+	if b.lex == nil {
+		return nil
+	}
+	g := b.generator(r, false)
+	g.generateLine(dir, line)
+
+	sort.SliceStable(g.moves, func(i, j int) bool {
+		return g.moves[i].before(g.moves[j])
+	})
+	return g.moves
+}
+
+func (b *board) generator(r rack, top bool) *generator {
+	g := &generator{
+		board:    b,
+		rack:     make(map[string]int),
+		alphabet: b.distrib.alphabet(),
+		top:      top,
+	}
+	for _, t := range r {
+		g.rack[t.L]++
+	}
+	return g
+}
+
+// topMove returns the highest-scoring move that can
+// be played with the tiles of the rack. The other moves
+// are not kept, since the long racks have many of them.
+func (b *board) topMove(r rack) *move {
+	moves := b.findMoves(r, true)
+	if len(moves) == 0 {
+		return nil
+	}
+	return &moves[0]
+}
+
+// find returns the move described by the notation, made
+// of a position and a word, that can be played with the
+// tiles of the rack. If the blank tiles are not written in
+// lowercase, the move that scores the most is returned.
+func (b *board) find(r rack, notation string) (*move, error) {
+	pos, word, ok := strings.Cut(strings.TrimSpace(notation), " ")
+	if !ok {
+		return nil, fmt.Errorf("invalid move %q, expected a position and a word, such as H8 WORD", notation)
+	}
+	pos = strings.ToUpper(pos)
+	word = strings.TrimSpace(word)
+
+	row, col, dir, err := parsePosition(pos)
+	if err != nil {
+		return nil, err
+	}
+	line := row
+	if dir == down {
+		line = col
+	}
+	var (
+		found *move
+		nw    = b.distrib.normalizer()(word)
+	)
+	moves := b.lineMoves(r, dir, line)
+	for i := range moves {
+		m := &moves[i]
+		if m.position() != pos {
+			continue
+		}
+		if m.notation() == pos+" "+word {
+			return m, nil
+		}
+		if found == nil && m.word() == nw {
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("move %s %s cannot be played", pos, word)
+	}
+	return found, nil
+}
+
+// parsePosition returns the coordinates and the direction
+// of a move from its position in the duplicate notation.
+func parsePosition(pos string) (row, col int, dir direction, err error) {
+	if pos == "" {
+		return 0, 0, 0, fmt.Errorf("empty position")
+	}
+	l, n := pos[:1], pos[1:]
+	if c := pos[len(pos)-1]; c >= 'A' && c <= 'Z' {
+		dir = down
+		l, n = pos[len(pos)-1:], pos[:len(pos)-1]
+	}
+	row = int(l[0] - 'A')
+	col, err = strconv.Atoi(n)
+	if err != nil || row < 0 || row >= boardSize || col < 1 || col > boardSize {
+		return 0, 0, 0, fmt.Errorf("invalid position: %s", pos)
+	}
+	return row, col - 1, dir, nil
+}
+
+// crossCheckAt computes the constraints of the perpendicular
+// word formed by a letter placed on the empty square at the
+// given coordinates, for a move in the given direction.
+func (b *board) crossCheckAt(row, col int, dir direction) crossCheck {
+	dr, dc := 1, 0
+	if dir == down {
+		dr, dc = 0, 1
+	}
+	var (
+		cc     crossCheck
+		before []string
+		after  []string
+	)
+	for r, c := row-dr, col-dc; b.filled(r, c); r, c = r-dr, c-dc {
+		sq := b.squares[r][c]
+		before = append([]string{sq.L}, before...)
+		cc.score += int(sq.points)
+	}
+	for r, c := row+dr, col+dc; b.filled(r, c); r, c = r+dr, c+dc {
+		sq := b.squares[r][c]
+		after = append(after, sq.L)
+		cc.score += int(sq.points)
+	}
+	if len(before) == 0 && len(after) == 0 {
+		return cc
+	}
+	cc.neighbors = true
+	cc.allowed = make(map[string]bool)
+
+	prefix := joinLetters(before)
+	suffix := joinLetters(after)
+
+	for _, l := range b.distrib.alphabet() {
+		if b.lex.contains(prefix + encodeLetter(l) + suffix) {
+			cc.allowed[l] = true
+		}
+	}
+	return cc
+}
+
+// isAnchor returns whether a move can be built from the
+// square at the given coordinates. An anchor is an empty
+// square adjacent to a letter, or the center square if
+// the board is empty.
+func (b *board) isAnchor(row, col int) bool {
+	if !b.squares[row][col].isEmpty() {
+		return false
+	}
+	if b.isEmpty() {
+		return row == boardSize/2 && col == boardSize/2
+	}
+	return b.filled(row-1, col) || b.filled(row+1, col) ||
+		b.filled(row, col-1) || b.filled(row, col+1)
+}
+
+func (b *board) view() string {
+	var (
+		sb    strings.Builder
+		empty = faintText.Render("·")
+	)
+	sb.WriteString("  ")
+	for i := 1; i <= boardSize; i++ {
+		sb.WriteString(faintText.Render(fmt.Sprintf("%3d", i)))
+	}
+	for i := 0; i < boardSize; i++ {
+		sb.WriteByte('\n')
+		sb.WriteString(faintText.Render(fmt.Sprintf("%2c", 'A'+i)))
+
+		for j := 0; j < boardSize; j++ {
+			sq := b.squares[i][j]
+			sb.WriteString("  ")
+
+			if !sq.isEmpty() {
+				l := sq.L
+				if sq.blank {
+					l = strings.ToLower(l)
+				}
+				sb.WriteString(boldText.Render(l))
+				continue
+			}
+			if s, ok := premiumStyles[sq.bonus]; ok {
+				sb.WriteString(s.Render("■"))
+			} else {
+				sb.WriteString(empty)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// before returns whether the move is ranked before the
+// other: by descending score, then by position and word.
+func (m move) before(o move) bool {
+	if m.score != o.score {
+		return m.score > o.score
+	}
+	if pm, po := m.position(), o.position(); pm != po {
+		return pm < po
+	}
+	return m.word() < o.word()
+}
+
+// coords returns the board coordinates of the i-th cell.
+func (m move) coords(i int) (row, col int) {
+	if m.dir == across {
+		return m.row, m.col + i
+	}
+	return m.row + i, m.col
+}
+
+func (m move) word() string {
+	s := make([]string, 0, len(m.cells))
+	for _, c := range m.cells {
+		s = append(s, c.L)
+	}
+	return strings.Join(s, "")
+}
+
+// tiles returns the letters of the rack tiles used to
+// play the move, where blank tiles are represented as is.
+func (m move) tiles() []string {
+	var s []string
+	for i, c := range m.cells {
+		if !m.fresh[i] {
+			continue
+		}
+		if c.blank {
+			s = append(s, blank)
+		} else {
+			s = append(s, c.L)
+		}
+	}
+	return s
+}
+
+// tileCount returns the number of tiles placed by the move.
+func (m move) tileCount() int {
+	n := 0
+	for _, f := range m.fresh {
+		if f {
+			n++
+		}
+	}
+	return n
+}
+
+// position returns the coordinates of the move using the
+// duplicate notation: the row letter comes first for an
+// across move, and the column number for a down move.
+func (m move) position() string {
+	row := string(rune('A' + m.row))
+	col := strconv.Itoa(m.col + 1)
+
+	if m.dir == across {
+		return row + col
+	}
+	return col + row
+}
+
+// notation returns the position of the move followed
+// by its word, where blank tiles are written in lowercase.
+func (m move) notation() string {
+	s := make([]string, 0, len(m.cells))
+	for _, c := range m.cells {
+		if c.blank {
+			s = append(s, strings.ToLower(c.L))
+		} else {
+			s = append(s, c.L)
+		}
+	}
+	return m.position() + " " + strings.Join(s, "")
+}
+
+func (m move) String() string {
+	return fmt.Sprintf("%s (%d)", m.notation(), m.score)
+}
+
+// generator implements the move generation algorithm
+// described by Appel and Jacobson in "The World's Fastest
+// Scrabble Program", using the lexicon to check prefixes.
+type generator struct {
+	board    *board
+	rack     map[string]int
+	alphabet []string
+	top      bool
+	dir      direction
+	line     int
+	checks   [boardSize]crossCheck
+	cells    []cell
+	fresh    []bool
+	// encoded holds the letters of the cells joined
+	// as the words of the lexicon, for each length.
+	encoded []string
+	moves   []move
+}
+
+func (g *generator) generate(dir direction) {
+	for line := 0; line < boardSize; line++ {
+		g.generateLine(dir, line)
+	}
+}
+
+// generateLine finds the moves of a row, for across
+// moves, or of a column, for down moves.
+func (g *generator) generateLine(dir direction, line int) {
+	g.dir, g.line = dir, line
+
+	// Compute the cross-checks of all empty
+	// squares of the line beforehand.
+	for pos := 0; pos < boardSize; pos++ {
+		row, col := g.coords(pos)
+		if g.board.squares[row][col].isEmpty() {
+			g.checks[pos] = g.board.crossCheckAt(row, col, dir)
+		} else {
+			g.checks[pos] = crossCheck{}
+		}
+	}
+	for pos := 0; pos < boardSize; pos++ {
+		if !g.board.isAnchor(g.coords(pos)) {
+			continue
+		}
+		g.cells, g.fresh, g.encoded = g.cells[:0], g.fresh[:0], g.encoded[:0]
+
+		// If the square before the anchor holds a
+		// letter, the left part of the word is made
+		// of all the letters already placed.
+		if pos > 0 && !g.board.at(line, pos-1, dir).isEmpty() {
+			start := pos - 1
+			for start > 0 && !g.board.at(line, start-1, dir).isEmpty() {
+				start--
+			}
+			for i := start; i < pos; i++ {
+				g.push(g.board.at(line, i, dir).cell, false)
+			}
+			if g.board.lex.hasPrefix(g.word()) {
+				g.extendRight(pos, pos)
+			}
+			continue
+		}
+		// Otherwise, the left part can be built from
+		// the tiles of the rack over the empty squares
+		// that aren't anchors.
+		limit := 0
+		for i := pos - 1; i >= 0; i-- {
+			row, col := g.coords(i)
+			if g.board.isAnchor(row, col) || !g.board.squares[row][col].isEmpty() {
+				break
+			}
+			limit++
+		}
+		g.leftPart(pos, limit)
+	}
+}
+
+func (g *generator) leftPart(anchor, limit int) {
+	g.extendRight(anchor, anchor)
+
+	if limit == 0 {
+		return
+	}
+	g.eachTile(func(c cell) {
+		if !g.board.lex.hasPrefix(g.word() + encodeLetter(c.L)) {
+			return
+		}
+		g.push(c, true)
+		g.leftPart(anchor, limit-1)
+		g.pop()
+	})
+}
+
+func (g *generator) extendRight(pos, anchor int) {
+	if pos >= boardSize || g.board.at(g.line, pos, g.dir).isEmpty() {
+		if pos > anchor && g.board.lex.contains(g.word()) {
+			g.record(pos - len(g.cells))
+		}
+		if pos >= boardSize {
+			return
+		}
+		cc := g.checks[pos]
+
+		g.eachTile(func(c cell) {
+			if !cc.allows(c.L) || !g.board.lex.hasPrefix(g.word()+encodeLetter(c.L)) {
+				return
+			}
+			g.push(c, true)
+			g.extendRight(pos+1, anchor)
+			g.pop()
+		})
+		return
+	}
+	sq := g.board.at(g.line, pos, g.dir)
+
+	if g.board.lex.hasPrefix(g.word() + encodeLetter(sq.L)) {
+		g.push(sq.cell, false)
+		g.extendRight(pos+1, anchor)
+		g.pop()
+	}
+}
+
+// eachTile calls fn for every letter that can be placed
+// from the rack, withdrawing the tile for the duration of
+// the call. A blank tile can represent any letter.
+func (g *generator) eachTile(fn func(c cell)) {
+	for _, l := range g.alphabet {
+		if g.rack[l] == 0 {
+			continue
+		}
+		g.rack[l]--
+		fn(cell{L: l, points: g.board.distrib.points(l)})
+		g.rack[l]++
+	}
+	if g.rack[blank] == 0 {
+		return
+	}
+	g.rack[blank]--
+	for _, l := range g.alphabet {
+		fn(cell{L: l, blank: true})
+	}
+	g.rack[blank]++
+}
+
+func (g *generator) record(start int) {
+	m := move{
+		dir:   g.dir,
+		cells: g.cells,
+		fresh: g.fresh,
+	}
+	if g.dir == across {
+		m.row, m.col = g.line, start
+	} else {
+		m.row, m.col = start, g.line
+	}
+	var (
+		main  int
+		cross int
+		mul   = 1
+	)
+	for i, c := range m.cells {
+		if !m.fresh[i] {
+			main += int(c.points)
+			continue
+		}
+		row, col := m.coords(i)
+		lm, wm := g.board.squares[row][col].bonus.multipliers()
+		main += int(c.points) * lm
+		mul *= wm
+
+		if cc := g.checks[start+i]; cc.neighbors {
+			cross += (cc.score + int(c.points)*lm) * wm
+		}
+	}
+	m.score = main*mul + cross
+
+	if m.tileCount() >= bingoTiles {
+		m.score += bingoBonus
+	}
+	// The cells of the move are still the buffers of the
+	// generator, which are only read until the move is kept
+	// and cloned, since the search goes on with them.
+	if g.top && len(g.moves) != 0 {
+		if !m.before(g.moves[0]) {
+			return
+		}
+		g.moves = g.moves[:0]
+	}
+	m.cells, m.fresh = slices.Clone(m.cells), slices.Clone(m.fresh)
+
+	g.moves = append(g.moves, m)
+}
+
+func (g *generator) coords(pos int) (row, col int) {
+	if g.dir == across {
+		return g.line, pos
+	}
+	return pos, g.line
+}
+
+// word returns the letters of the cells, joined in the
+// same way as the words of the lexicon.
+func (g *generator) word() string {
+	if len(g.encoded) == 0 {
+		return ""
+	}
+	return g.encoded[len(g.encoded)-1]
+}
+
+func (g *generator) push(c cell, fresh bool) {
+	g.encoded = append(g.encoded, g.word()+encodeLetter(c.L))
+	g.cells = append(g.cells, c)
+	g.fresh = append(g.fresh, fresh)
+}
+
+func (g *generator) pop() {
+	g.cells = g.cells[:len(g.cells)-1]
+	g.fresh = g.fresh[:len(g.fresh)-1]
+	g.encoded = g.encoded[:len(g.encoded)-1]
+}
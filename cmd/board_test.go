@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+var cachedLexicon wordSet
+
+func Test_newBoard(t *testing.T) {
+	b := newBoard(english, nil)
+
+	counts := make(map[premium]int)
+	for _, row := range b.squares {
+		for _, sq := range row {
+			counts[sq.bonus]++
+		}
+	}
+	for p, n := range map[premium]int{
+		premiumTripleWord:   8,
+		premiumDoubleWord:   17,
+		premiumTripleLetter: 12,
+		premiumDoubleLetter: 24,
+	} {
+		if counts[p] != n {
+			t.Errorf("expected %d squares with premium %d, got %d", n, p, counts[p])
+		}
+	}
+	if c := b.squares[7][7].bonus; c != premiumDoubleWord {
+		t.Errorf("expected center square to be a double word")
+	}
+}
+
+func Test_board_topMove(t *testing.T) {
+	lex := lexicon{"ACT", "AT", "CAT", "CATS", "SCAT", "TA"}
+	slices.Sort(lex)
+
+	b := newBoard(english, lex)
+
+	// On an empty board, the first move must
+	// cover the center square (double word).
+	m := b.topMove(tilesFromWord("CATS", english))
+	if m == nil {
+		t.Fatal("expected a move")
+	}
+	if m.word() != "CATS" && m.word() != "SCAT" {
+		t.Errorf("expected top word to be CATS or SCAT, got %s", m.word())
+	}
+	if m.score != 12 {
+		t.Errorf("expected top score to be 12, got %d", m.score)
+	}
+	b.place(move{
+		row:   7,
+		col:   7,
+		dir:   across,
+		cells: []cell{{"C", 3, false}, {"A", 1, false}, {"T", 1, false}},
+		fresh: []bool{true, true, true},
+	})
+	// Adding an S on either side of CAT scores the
+	// whole word, the first position wins the tie.
+	m = b.topMove(tilesFromWord("S", english))
+	if m == nil {
+		t.Fatal("expected a move")
+	}
+	if got, want := m.String(), "H7 SCAT (6)"; got != want {
+		t.Errorf("got move %q, want %q", got, want)
+	}
+	// A blank tile scores no points, but the
+	// other letters of the word still count.
+	m = b.topMove(rack{{letter: letter{L: blank}}})
+	if m == nil {
+		t.Fatal("expected a move")
+	}
+	if got, want := m.String(), "H7 sCAT (5)"; got != want {
+		t.Errorf("got move %q, want %q", got, want)
+	}
+	// The top move of a long rack is the
+	// first of all the moves, sorted.
+	r := append(tilesFromWord("SCATACTS", english), tile{letter: letter{L: blank}})
+	if got, want := b.topMove(r).String(), b.moves(r)[0].String(); got != want {
+		t.Errorf("got move %q, want %q", got, want)
+	}
+}
+
+func Test_board_topMove_fullRack(t *testing.T) {
+	lex := lexicon{"DEMOCRAT", "DEMOCRATIZATION", "RATION", "ZIT"}
+	slices.Sort(lex)
+
+	b := newBoard(english, lex)
+
+	// A rack of 15 tiles fills a whole row of the board,
+	// and the kept move does not share the buffers that
+	// the search goes on with.
+	r := tilesFromWord("NOITAZITARCOMED", english)
+
+	m := b.topMove(r)
+	if m == nil {
+		t.Fatal("expected a move")
+	}
+	if got, want := m.String(), b.moves(r)[0].String(); got != want {
+		t.Errorf("got move %q, want %q", got, want)
+	}
+	if m.word() != "DEMOCRATIZATION" || m.tileCount() != boardSize {
+		t.Errorf("expected the move to use all the tiles, got %s", m)
+	}
+	// Both directions score the same, through the center.
+	if m.row+m.col != 7 || (m.dir == across) != (m.row == 7) {
+		t.Errorf("expected the move to fill the center line, got %s", m)
+	}
+}
+
+func Test_board_crossCheckAt(t *testing.T) {
+	lex := lexicon{"AT", "CAT", "TA"}
+	slices.Sort(lex)
+
+	b := newBoard(english, lex)
+	b.place(move{
+		row:   7,
+		col:   7,
+		dir:   across,
+		cells: []cell{{"C", 3, false}, {"A", 1, false}, {"T", 1, false}},
+		fresh: []bool{true, true, true},
+	})
+	// Above the A, only a T forms a valid
+	// vertical word (TA) for an across move.
+	cc := b.crossCheckAt(6, 8, across)
+	if !cc.neighbors {
+		t.Fatal("expected square to have neighbors")
+	}
+	if !cc.allows("T") || cc.allows("C") {
+		t.Errorf("unexpected cross-check letters: %v", cc.allowed)
+	}
+	if cc.score != 1 {
+		t.Errorf("expected cross-check score of 1, got %d", cc.score)
+	}
+	if cc := b.crossCheckAt(0, 0, across); cc.neighbors || !cc.allows("Z") {
+		t.Errorf("expected square without neighbors to allow any letter")
+	}
+}
+
+func Test_board_topMove_french(t *testing.T) {
+	b := newBoard(french, frenchLexicon(t))
+
+	m := b.topMove(tilesFromWord("OCBSWYO", french))
+	if m == nil {
+		t.Fatal("expected a move")
+	}
+	if m.word() != "COWBOYS" {
+		t.Errorf("expected top word to be COWBOYS, got %s", m.word())
+	}
+	// The W or the Y is placed on a double letter
+	// square, and the bingo bonus is added.
+	if m.score != 128 {
+		t.Errorf("expected top score to be 128, got %d", m.score)
+	}
+}
+
+func frenchLexicon(t *testing.T) wordSet {
+	t.Helper()
+
+	if cachedLexicon != nil {
+		return cachedLexicon
+	}
+	path := filepath.Join(dictDir, "french/ods8.txt.gz")
+
+	lex, err := loadLexiconFile(path, french)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachedLexicon = lex
+
+	return lex
+}
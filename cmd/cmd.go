@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,52 +20,226 @@ var (
 	consonants    uint8
 	wordLength    uint8
 	showPoints    bool
+	showBoard     bool
+	official      bool
 	debugLogFile  string
+	recordPath    string
+	resumePath    string
+	seed          int64
 	timerDuration time.Duration
 	predicates    predicateList
+	players       []string
+	foldAccents   bool
+	invalidWords  string
+	defsPath      string
+	distribFiles  []string
 
 	Root = &cobra.Command{
 		Use:  "scrabbler",
 		Long: "scrabbler — pick tiles, but not yourself!",
 		RunE: run,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			// The invalid distribution files only fail the
+			// commands that use them, and are reported by
+			// the verification.
+			return loadUserDistributions(distribFiles)
+		},
 	}
 )
 
 func init() {
 	setupFlags()
+	setupReplayFlags()
+	setupExportFlags()
+	setupGenerateFlags()
+	setupDictFlags()
+	setupSearchFlags()
+	setupDistributionsFlags()
+	setupWordPolicyFlags(
+		Root,
+		replayCmd,
+		generateCmd,
+		dictCompileCmd,
+		dictCheckCmd,
+		anagramCmd,
+		searchCmd,
+	)
+
+	Root.AddCommand(replayCmd)
+	Root.AddCommand(exportCmd)
+	Root.AddCommand(generateCmd)
+	Root.AddCommand(dictCmd)
+	Root.AddCommand(anagramCmd)
+	Root.AddCommand(searchCmd)
+	Root.AddCommand(distributionsCmd)
 }
 
 func run(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
 
 	dn := cmd.Flag("distribution").Value.String()
-	dp := cmd.Flag("dictionary").Value.String()
-
-	tw, th, err := term.GetSize(int(os.Stdin.Fd()))
+	dicts, err := dictionaryFlags(cmd)
 	if err != nil {
-		return fmt.Errorf("cannot get term size: %s", err)
+		return err
 	}
-	if wordLength < 7 || wordLength > 8 {
-		return fmt.Errorf("word length must be 7 or 8")
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return err
 	}
-	if vowels+consonants > wordLength {
-		return fmt.Errorf("required vowels and consonants exceed word length")
+	if resumePath != "" {
+		return resume(dicts, policy)
 	}
-	out := termenv.NewOutput(os.Stdout)
-	out.SetWindowTitle("scrabbler")
-
-	tui, err := newTUI(dn, tw, th, options{
-		dictPath:      dp,
+	if err := checkDrawFlags(); err != nil {
+		return err
+	}
+	for i, p := range players {
+		if p == "" || slices.Contains(players[:i], p) {
+			return fmt.Errorf("invalid or duplicate player name: %q", p)
+		}
+	}
+	// Without an explicit seed, use a random one,
+	// which is recorded to reproduce the game.
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	return runTUI(dn, options{
+		dicts:         dicts,
+		defsPath:      defsPath,
+		policy:        policy,
 		wordLength:    int(wordLength),
 		minVowels:     int(vowels),
 		minConsonants: int(consonants),
+		official:      official,
 		showPoints:    showPoints,
+		board:         showBoard,
 		timerDuration: timerDuration,
 		predicates:    predicates.value,
+		predicateArgs: predicates.args,
+		players:       players,
+		seed:          seed,
+		recordPath:    recordPath,
+	})
+}
+
+// checkDrawFlags validates the flags that configure
+// the draws of a game. The word length is checked once
+// the distribution is known, with drawLength.
+func checkDrawFlags() error {
+	if official && vowels+consonants != 0 {
+		return fmt.Errorf("the official rules define the required vowels and consonants")
+	}
+	return nil
+}
+
+// drawLength returns the number of tiles of the draws,
+// which defaults to that of the distribution if n is zero,
+// and must be within the word lengths of the distribution.
+// The official rules require two vowels and two consonants
+// during the first rounds.
+func drawLength(d distribution, n, minVowels, minConsonants int, official bool) (int, error) {
+	n, err := d.wordLength(n)
+	if err != nil {
+		return 0, err
+	}
+	if official && n < 4 {
+		return 0, fmt.Errorf("the official rules require a word length of at least 4")
+	}
+	if minVowels+minConsonants > n {
+		return 0, fmt.Errorf("required vowels and consonants exceed word length")
+	}
+	return n, nil
+}
+
+// resume continues the game recorded in the file, using
+// the settings of the record instead of the draw flags.
+func resume(dicts []dictSource, policy policyFlags) error {
+	rec, err := loadRecord(resumePath)
+	if err != nil {
+		return err
+	}
+	opts, err := rec.options(options{
+		dicts:         dicts,
+		defsPath:      defsPath,
+		policy:        policy,
+		showPoints:    showPoints,
+		board:         showBoard,
+		timerDuration: timerDuration,
+		recordPath:    resumePath,
+		resume:        rec,
 	})
 	if err != nil {
 		return err
 	}
+	return runTUI(rec.Distribution, opts)
+}
+
+// policyFlags holds the flags that override
+// the word policy of a distribution.
+type policyFlags struct {
+	fold   *bool
+	reject bool
+}
+
+// apply returns the policy overridden by the flags. The
+// accents are folded as defined by the distribution, unless
+// the flag is set explicitly.
+func (pf policyFlags) apply(p wordPolicy) wordPolicy {
+	if pf.fold != nil {
+		p.fold = *pf.fold
+	}
+	p.reject = pf.reject
+
+	return p
+}
+
+// wordPolicyFlags returns the flags of the
+// command that override the word policy.
+func wordPolicyFlags(cmd *cobra.Command) (policyFlags, error) {
+	var pf policyFlags
+
+	if cmd.Flags().Changed("fold-accents") {
+		fold := foldAccents
+		pf.fold = &fold
+	}
+	switch invalidWords {
+	case "skip":
+	case "reject":
+		pf.reject = true
+	default:
+		return pf, fmt.Errorf("invalid words must be skipped or rejected, got %q", invalidWords)
+	}
+	return pf, nil
+}
+
+// dictionaryFlags returns the dictionaries of the command,
+// which can be switched during a game.
+func dictionaryFlags(cmd *cobra.Command) ([]dictSource, error) {
+	values, err := cmd.Flags().GetStringArray("dictionary")
+	if err != nil {
+		return nil, err
+	}
+	return parseDictSources(values)
+}
+
+func runTUI(dn string, opts options) error {
+	// The dictionary may be read from the standard input,
+	// in which case the keys are read from the terminal.
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		fd = int(os.Stdout.Fd())
+	}
+	tw, th, err := term.GetSize(fd)
+	if err != nil {
+		return fmt.Errorf("cannot get term size: %s", err)
+	}
+	out := termenv.NewOutput(os.Stdout)
+	out.SetWindowTitle("scrabbler")
+
+	tui, err := newTUI(dn, tw, th, opts)
+	if err != nil {
+		return err
+	}
 	prg := tea.NewProgram(
 		tui,
 		tea.WithAltScreen(),
@@ -85,17 +260,21 @@ func run(cmd *cobra.Command, _ []string) error {
 	// See https://github.com/charmbracelet/lipgloss/issues/73
 	lipgloss.SetHasDarkBackground(termenv.HasDarkBackground())
 
-	_, err = prg.Run()
-
-	return err
+	if _, err := prg.Run(); err != nil {
+		return err
+	}
+	return tui.err
 }
 
 func setupFlags() {
 	f := Root.Flags()
 	f.SortFlags = false
 
-	f.StringP("dictionary", "d", "",
-		"custom dictionary file path",
+	f.StringArrayP("dictionary", "d", nil,
+		"custom dictionary file path or built-in name, or name=path (repeatable)",
+	)
+	f.StringVar(&defsPath, "definitions", "",
+		"file of the definitions of the words",
 	)
 	f.StringP("distribution", "l", "",
 		"letter distribution language",
@@ -106,22 +285,61 @@ func setupFlags() {
 	f.Uint8Var(&consonants, "consonants", 0,
 		"number of required consonant letters",
 	)
-	f.Uint8VarP(&wordLength, "word-length", "w", 7,
-		"the number of tiles to draw",
+	f.Uint8VarP(&wordLength, "word-length", "w", 0,
+		"the number of tiles to draw (default per distribution)",
 	)
 	f.BoolVarP(&showPoints, "show-points", "p", false,
 		"show letter points in tiles",
 	)
+	f.BoolVarP(&showBoard, "board", "b", false,
+		"track the board and find top moves",
+	)
+	f.BoolVar(&official, "official", false,
+		"apply the official duplicate rules",
+	)
 	f.Var(&predicates, "predicates",
 		"list of draw predicates",
 	)
+	f.StringSliceVar(&players, "players", nil,
+		"names of the players to score",
+	)
 	f.DurationVarP(&timerDuration, "timer", "t", 0,
 		"enable play timer (default 5m)",
 	)
+	f.Int64Var(&seed, "seed", 0,
+		"seed of the random draws (default random)",
+	)
+	f.StringVar(&recordPath, "record", "",
+		"record the game to a file",
+	)
+	f.StringVar(&resumePath, "resume", "",
+		"resume a game recorded to a file",
+	)
 	f.StringVar(&debugLogFile, "debug", "",
 		"enable debug mode",
 	)
 	// Set default value for flags used without option.
 	f.Lookup("debug").NoOptDefVal = "debug.log"
 	f.Lookup("timer").NoOptDefVal = "5m"
+
+	// The distributions of the user are
+	// available to all the commands.
+	Root.PersistentFlags().StringArrayVar(&distribFiles, "distribution-file", nil,
+		"file of a custom distribution (repeatable)",
+	)
+}
+
+// setupWordPolicyFlags adds the flags that define how the
+// words of the dictionaries are read to the commands.
+func setupWordPolicyFlags(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		f := c.Flags()
+
+		f.BoolVar(&foldAccents, "fold-accents", false,
+			"strip the accents missing from the distribution (default per distribution)",
+		)
+		f.StringVar(&invalidWords, "invalid-words", "skip",
+			"skip or reject the words with unknown letters",
+		)
+	}
 }
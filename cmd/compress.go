@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// stdinPath is the path of the dictionary
+// files read from the standard input.
+const stdinPath = "-"
+
+// stdin is read once, and kept in memory, since
+// a dictionary file may be opened several times.
+var (
+	stdin     io.Reader = os.Stdin
+	stdinOnce sync.Once
+	stdinData []byte
+	stdinErr  error
+)
+
+// file is an opened dictionary file,
+// whose content can be read at random.
+type file interface {
+	io.ReadSeekCloser
+	io.ReaderAt
+}
+
+// memFile is a file whose content is held in memory.
+type memFile struct {
+	*bytes.Reader
+}
+
+func (memFile) Close() error { return nil }
+
+// openFile opens the file at the given path,
+// or the standard input if the path is "-".
+func openFile(path string) (file, error) {
+	if path != stdinPath {
+		return os.Open(path)
+	}
+	stdinOnce.Do(func() {
+		stdinData, stdinErr = io.ReadAll(stdin)
+	})
+	if stdinErr != nil {
+		return nil, fmt.Errorf("failed to read standard input: %s", stdinErr)
+	}
+	return memFile{bytes.NewReader(stdinData)}, nil
+}
+
+// compression is a format of compressed
+// file, identified by its magic number.
+type compression struct {
+	name  string
+	magic []byte
+}
+
+var compressions = []compression{
+	{"gzip", []byte{0x1f, 0x8b}},
+	{"bzip2", []byte("BZh")},
+	{"xz", []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{"zstd", []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{"zip", []byte("PK\x03\x04")},
+}
+
+// detectCompression returns the name of the compression
+// format of the header, or an empty string if the content
+// is not compressed.
+func detectCompression(header []byte) string {
+	for _, c := range compressions {
+		if bytes.HasPrefix(header, c.magic) {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// decompressedFile reads the decompressed content of a file,
+// and closes the decompressor along with the file.
+type decompressedFile struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (df *decompressedFile) Close() error {
+	var err error
+	for i := len(df.closers) - 1; i >= 0; i-- {
+		if e := df.closers[i].Close(); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// decompress returns a reader that transparently decompresses
+// the content of the file. The file is closed along with it.
+// A zip archive is read from its first text file.
+func decompress(f file) (io.ReadCloser, error) {
+	br := bufio.NewReader(f)
+
+	header, err := br.Peek(8)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	df := &decompressedFile{closers: []io.Closer{f}}
+
+	switch detectCompression(header) {
+	case "gzip":
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		df.Reader = gr
+		df.closers = append(df.closers, gr)
+	case "bzip2":
+		df.Reader = bzip2.NewReader(br)
+	case "xz":
+		xr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		df.Reader = xr
+	case "zstd":
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		rc := zr.IOReadCloser()
+		df.Reader = rc
+		df.closers = append(df.closers, rc)
+	case "zip":
+		rc, err := openZipText(f)
+		if err != nil {
+			return nil, err
+		}
+		df.Reader = rc
+		df.closers = append(df.closers, rc)
+	default:
+		df.Reader = br
+	}
+	return df, nil
+}
+
+// openZipText opens the first file of the
+// zip archive with a .txt extension.
+func openZipText(f file) (io.ReadCloser, error) {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(f, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, zf := range zr.File {
+		if strings.EqualFold(path.Ext(zf.Name), ".txt") {
+			return zf.Open()
+		}
+	}
+	return nil, errors.New("no .txt file found in zip archive")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const compressedWords = "cat\nact\nzoo\n"
+
+// bzip2Words is compressedWords compressed with bzip2,
+// since the standard library only provides a decoder.
+const bzip2Words = "\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\xd0\xdb\x27\x2f\x00\x00" +
+	"\x02\xc1\x80\x00\x10\x28\x00\x84\x10\x20\x00\x30\xc0\x08\x68\xfd" +
+	"\x50\x4c\x43\xb0\xf1\x77\x24\x53\x85\x09\x0d\x0d\xb2\x72\xf0"
+
+func Test_openDictionaryFile_compressions(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		compress func(w io.Writer) (io.WriteCloser, error)
+		raw      string
+	}{
+		{name: "plain", raw: compressedWords},
+		{name: "bzip2", raw: bzip2Words},
+		{
+			name: "gzip",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				return gzip.NewWriter(w), nil
+			},
+		},
+		{
+			name: "xz",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				return xz.NewWriter(w)
+			},
+		},
+		{
+			name: "zstd",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				return zstd.NewWriter(w)
+			},
+		},
+		{
+			name: "zip",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				zw := zip.NewWriter(w)
+				readme, err := zw.Create("README.md")
+				if err != nil {
+					return nil, err
+				}
+				if _, err := io.WriteString(readme, "# Word list\n"); err != nil {
+					return nil, err
+				}
+				f, err := zw.Create("words/LIST.TXT")
+				if err != nil {
+					return nil, err
+				}
+				return zipEntry{Writer: f, zw: zw}, nil
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if tt.compress != nil {
+				w, err := tt.compress(&buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := io.WriteString(w, compressedWords); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				buf.WriteString(tt.raw)
+			}
+			path := filepath.Join(t.TempDir(), "words")
+			if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			r, err := openDictionaryFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != compressedWords {
+				t.Errorf("got content %q, want %q", got, compressedWords)
+			}
+		})
+	}
+}
+
+func Test_openDictionaryFile_zipWithoutText(t *testing.T) {
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	if _, err := zw.Create("README.md"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "words.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openDictionaryFile(path); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func Test_loadDictionaryFile_stdin(t *testing.T) {
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	_, _ = io.WriteString(gw, compressedWords)
+	_ = gw.Close()
+
+	stdin = &buf
+	stdinOnce = sync.Once{}
+	t.Cleanup(func() {
+		stdin = os.Stdin
+		stdinOnce = sync.Once{}
+		stdinData = nil
+	})
+	// The standard input can be read several times,
+	// such as for the dictionary and the lexicon.
+	for i := 0; i < 2; i++ {
+		wf, err := loadDictionaryFile(stdinPath, english, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		words := wf.findWords(tilesFromWord("TAC", english), english)
+		if got, want := strings.Join(words, ","), "CAT,ACT"; got != want {
+			t.Errorf("got words %s, want %s", got, want)
+		}
+	}
+}
+
+// zipEntry closes the archive along with its entry.
+type zipEntry struct {
+	io.Writer
+	zw *zip.Writer
+}
+
+func (ze zipEntry) Close() error {
+	return ze.zw.Close()
+}
@@ -0,0 +1,761 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// dawgMagic identifies the files of compiled dictionaries.
+const (
+	dawgMagic   = "SCRBDAWG"
+	dawgVersion = 1
+)
+
+// Layout of an edge of the graph, encoded as an uint32. The
+// lower bits are the index of the first edge of the child
+// node, followed by the flags and the index of the letter
+// in the alphabet of the graph.
+const (
+	edgeChildBits   = 24
+	edgeChildMask   = 1<<edgeChildBits - 1
+	edgeTerminal    = 1 << 24
+	edgeLast        = 1 << 25
+	edgeLetterShift = 26
+	maxDawgLetters  = 1 << (32 - edgeLetterShift)
+)
+
+// rootNode is the index of the first edge of the root
+// node. The first edge is reserved, so that a child index
+// of zero means that the node has no children.
+const rootNode = 1
+
+// dawg is a directed acyclic word graph, a compact
+// representation of the words of a dictionary in which
+// the common prefixes and suffixes are shared. The words
+// are made of the letters of a distribution, such that a
+// digraph is a single edge.
+type dawg struct {
+	lang     language.Tag
+	hash     [sha256.Size]byte
+	alphabet []string
+	index    map[string]int
+	edges    []uint32
+	// skipped is the number of words with letters outside
+	// of the distribution, when compiled from a word list.
+	skipped int
+}
+
+func edgeLetter(e uint32) int   { return int(e >> edgeLetterShift) }
+func edgeChild(e uint32) uint32 { return e & edgeChildMask }
+
+// buildDawg builds the graph of the words, given as sequences
+// of letters, using the incremental algorithm of Daciuk et al.
+// for sorted input, which only keeps the minimized graph and
+// the nodes of the last word in memory.
+func buildDawg(words [][]string, lang language.Tag) (*dawg, error) {
+	g := &dawg{
+		lang:  lang,
+		index: make(map[string]int),
+	}
+	for _, w := range words {
+		for _, l := range w {
+			if _, ok := g.index[l]; !ok {
+				g.index[l] = 0
+				g.alphabet = append(g.alphabet, l)
+			}
+		}
+	}
+	if len(g.alphabet) > maxDawgLetters {
+		return nil, fmt.Errorf("too many distinct letters: %d (max %d)", len(g.alphabet), maxDawgLetters)
+	}
+	slices.Sort(g.alphabet)
+	for i, l := range g.alphabet {
+		g.index[l] = i
+	}
+	seqs := make([][]uint8, 0, len(words))
+	for _, w := range words {
+		if len(w) == 0 {
+			continue
+		}
+		s := make([]uint8, len(w))
+		for i, l := range w {
+			s[i] = uint8(g.index[l])
+		}
+		seqs = append(seqs, s)
+	}
+	slices.SortFunc(seqs, slices.Compare[[]uint8])
+	seqs = slices.CompactFunc(seqs, slices.Equal[[]uint8])
+
+	b := dawgBuilder{
+		root:      &dawgNode{},
+		minimized: make(map[string]*dawgNode),
+	}
+	for _, s := range seqs {
+		b.insert(s)
+	}
+	b.minimize(0)
+
+	edges, err := b.root.flatten()
+	if err != nil {
+		return nil, err
+	}
+	g.edges = edges
+
+	return g, nil
+}
+
+type dawgNode struct {
+	final bool
+	edges []dawgEdge
+	id    int
+}
+
+type dawgEdge struct {
+	letter uint8
+	child  *dawgNode
+}
+
+// key returns a string that identifies the node by its
+// finality and its edges. The children must be minimized.
+func (n *dawgNode) key() string {
+	b := make([]byte, 0, 1+len(n.edges)*4)
+	if n.final {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	for _, e := range n.edges {
+		b = append(b, e.letter)
+		b = binary.AppendUvarint(b, uint64(e.child.id))
+	}
+	return string(b)
+}
+
+// flatten returns the edges of the graph whose root is
+// the node, where the edges of each node are contiguous.
+func (n *dawgNode) flatten() ([]uint32, error) {
+	var (
+		offsets = map[*dawgNode]uint32{n: rootNode}
+		order   = []*dawgNode{n}
+		next    = uint32(rootNode + len(n.edges))
+	)
+	for i := 0; i < len(order); i++ {
+		for _, e := range order[i].edges {
+			c := e.child
+			if len(c.edges) == 0 {
+				continue
+			}
+			if _, ok := offsets[c]; !ok {
+				offsets[c] = next
+				next += uint32(len(c.edges))
+				order = append(order, c)
+			}
+		}
+	}
+	if next > edgeChildMask {
+		return nil, fmt.Errorf("too many edges: %d (max %d)", next, edgeChildMask)
+	}
+	edges := make([]uint32, next)
+
+	for _, node := range order {
+		off := offsets[node]
+		for i, e := range node.edges {
+			v := uint32(e.letter) << edgeLetterShift
+			if e.child.final {
+				v |= edgeTerminal
+			}
+			if len(e.child.edges) != 0 {
+				v |= offsets[e.child]
+			}
+			if i == len(node.edges)-1 {
+				v |= edgeLast
+			}
+			edges[off+uint32(i)] = v
+		}
+	}
+	return edges, nil
+}
+
+type dawgBuilder struct {
+	root      *dawgNode
+	prev      []uint8
+	unchecked []dawgEdge
+	parents   []*dawgNode
+	minimized map[string]*dawgNode
+	nextID    int
+}
+
+func (b *dawgBuilder) insert(word []uint8) {
+	common := 0
+	for common < len(word) && common < len(b.prev) && word[common] == b.prev[common] {
+		common++
+	}
+	b.minimize(common)
+
+	node := b.root
+	if len(b.unchecked) != 0 {
+		node = b.unchecked[len(b.unchecked)-1].child
+	}
+	for _, l := range word[common:] {
+		next := &dawgNode{}
+		node.edges = append(node.edges, dawgEdge{letter: l, child: next})
+		b.unchecked = append(b.unchecked, dawgEdge{letter: l, child: next})
+		b.parents = append(b.parents, node)
+		node = next
+	}
+	node.final = true
+	b.prev = word
+}
+
+// minimize replaces the unchecked nodes, down to the given
+// depth, by an equivalent node of the graph if it exists.
+func (b *dawgBuilder) minimize(depth int) {
+	for i := len(b.unchecked) - 1; i >= depth; i-- {
+		child := b.unchecked[i].child
+		parent := b.parents[i]
+
+		key := child.key()
+		if n, ok := b.minimized[key]; ok {
+			// The child is always the last
+			// edge added to its parent.
+			parent.edges[len(parent.edges)-1].child = n
+		} else {
+			b.nextID++
+			child.id = b.nextID
+			b.minimized[key] = child
+		}
+	}
+	b.unchecked = b.unchecked[:depth]
+	b.parents = b.parents[:depth]
+}
+
+// walk follows the edges of the letters from the root, and
+// returns whether the path exists, and if its last edge ends
+// a word.
+func (g *dawg) walk(letters []string) (ok, terminal bool) {
+	node := uint32(rootNode)
+
+	for i, l := range letters {
+		idx, ok := g.index[l]
+		if !ok || node == 0 || int(node) >= len(g.edges) {
+			return false, false
+		}
+		found := false
+		for j := node; ; j++ {
+			e := g.edges[j]
+			if edgeLetter(e) == idx {
+				found = true
+				terminal = e&edgeTerminal != 0
+				node = edgeChild(e)
+				break
+			}
+			if e&edgeLast != 0 {
+				break
+			}
+		}
+		if !found {
+			return false, false
+		}
+		if i == len(letters)-1 {
+			return true, terminal
+		}
+	}
+	return len(g.edges) > rootNode, false
+}
+
+// contains returns whether the word, whose letters are
+// encoded with joinLetters, is part of the dictionary.
+func (g *dawg) contains(w string) bool {
+	ok, terminal := g.walk(splitLetters(w))
+	return ok && terminal
+}
+
+// hasPrefix returns whether at least one word of
+// the dictionary starts with the encoded prefix.
+func (g *dawg) hasPrefix(p string) bool {
+	ok, _ := g.walk(splitLetters(p))
+	return ok
+}
+
+// dawgSearch walks the graph with the tiles of a rack,
+// where blank tiles can stand for any letter, and visits
+// every word that can be formed along the way.
+type dawgSearch struct {
+	g       *dawg
+	counts  []int
+	blanks  int
+	left    int
+	missing bool
+	word    []int
+	blank   []bool
+	visit   func(word []int, blank []bool)
+
+	// sorted restricts the search to the words formed
+	// with all the tiles, in a graph of sorted letters.
+	sorted bool
+}
+
+func (g *dawg) search(tiles rack, visit func(word []int, blank []bool)) {
+	g.newSearch(tiles, visit).run()
+}
+
+// searchSorted visits the words formed with all the tiles,
+// in a graph whose words are made of sorted letters, such as
+// the keys of an indexedDict. Since the letters of a path never
+// decrease, the walk stops as soon as a letter of the tiles
+// that is not used yet can no longer be reached.
+func (g *dawg) searchSorted(tiles rack, visit func(word []int, blank []bool)) {
+	s := g.newSearch(tiles, visit)
+	s.sorted = true
+	s.run()
+}
+
+func (g *dawg) newSearch(tiles rack, visit func(word []int, blank []bool)) *dawgSearch {
+	s := &dawgSearch{
+		g:      g,
+		counts: make([]int, len(g.alphabet)),
+		left:   len(tiles),
+		visit:  visit,
+	}
+	for _, t := range tiles {
+		if t.L == blank {
+			s.blanks++
+		} else if idx, ok := g.index[t.L]; ok {
+			s.counts[idx]++
+		} else {
+			s.missing = true
+		}
+	}
+	return s
+}
+
+func (s *dawgSearch) run() {
+	if len(s.g.edges) <= rootNode {
+		return
+	}
+	// A letter that is not part of any word
+	// cannot be used with all the tiles.
+	if s.sorted && s.missing {
+		return
+	}
+	s.walk(rootNode)
+}
+
+// unreachable returns whether a letter of the tiles
+// precedes the letter l, and thus cannot be used.
+func (s *dawgSearch) unreachable(l int) bool {
+	for _, c := range s.counts[:l] {
+		if c > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *dawgSearch) walk(node uint32) {
+	for i := node; ; i++ {
+		e := s.g.edges[i]
+		l := edgeLetter(e)
+
+		if s.sorted && s.unreachable(l) {
+			break
+		}
+		if s.counts[l] > 0 {
+			s.counts[l]--
+			s.left--
+			s.step(e, l, false)
+			s.counts[l]++
+			s.left++
+		}
+		// In a graph of sorted letters, a blank only stands
+		// for a letter once all its tiles are used, so that
+		// each word is visited once.
+		if s.blanks > 0 && (!s.sorted || s.counts[l] == 0) {
+			s.blanks--
+			s.left--
+			s.step(e, l, true)
+			s.blanks++
+			s.left++
+		}
+		if e&edgeLast != 0 {
+			break
+		}
+	}
+}
+
+func (s *dawgSearch) step(e uint32, l int, blank bool) {
+	s.word = append(s.word, l)
+	s.blank = append(s.blank, blank)
+
+	if e&edgeTerminal != 0 && (!s.sorted || s.left == 0) {
+		s.visit(s.word, s.blank)
+	}
+	if c := edgeChild(e); c != 0 && (!s.sorted || s.left > 0) {
+		s.walk(c)
+	}
+	s.word = s.word[:len(s.word)-1]
+	s.blank = s.blank[:len(s.blank)-1]
+}
+
+// wordOf returns the word made of the letters.
+func (g *dawg) wordOf(letters []int) string {
+	var sb strings.Builder
+	for _, l := range letters {
+		sb.WriteString(g.alphabet[l])
+	}
+	return sb.String()
+}
+
+// keyOf returns the letters encoded with joinLetters.
+func (g *dawg) keyOf(letters []int) string {
+	var sb strings.Builder
+	for _, l := range letters {
+		sb.WriteString(encodeLetter(g.alphabet[l]))
+	}
+	return sb.String()
+}
+
+// findWords returns the words that can
+// be formed with all the tiles, sorted.
+func (g *dawg) findWords(tiles rack, _ distribution) []string {
+	var (
+		words []string
+		seen  = make(map[string]bool)
+	)
+	g.search(tiles, func(word []int, _ []bool) {
+		if len(word) != len(tiles) {
+			return
+		}
+		w := g.wordOf(word)
+		if !seen[w] {
+			seen[w] = true
+			words = append(words, w)
+		}
+	})
+	sort.Strings(words)
+
+	return words
+}
+
+// findSubWords is the equivalent of indexedDict.findSubWords.
+// When a word can be formed with or without the blank tiles,
+// its best raw score is kept.
+func (g *dawg) findSubWords(tiles rack, d distribution, minLen int) []anagram {
+	scores := make(map[string]anagram)
+
+	g.search(tiles, func(word []int, blank []bool) {
+		if len(word) < minLen {
+			return
+		}
+		score := 0
+		for i, l := range word {
+			if !blank[i] {
+				score += int(d.points(g.alphabet[l]))
+			}
+		}
+		w := g.wordOf(word)
+		if a, ok := scores[w]; !ok || a.score < score {
+			scores[w] = anagram{
+				word:   w,
+				length: len(word),
+				score:  score,
+			}
+		}
+	})
+	found := make([]anagram, 0, len(scores))
+	for _, a := range scores {
+		found = append(found, a)
+	}
+	sortAnagrams(found)
+
+	return found
+}
+
+func (g *dawg) findWordsWithExtra(tiles rack, d distribution) []extraWords {
+	return wordsWithExtra(g, tiles, d)
+}
+
+// write writes the graph in its binary format.
+func (g *dawg) write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	b := []byte(dawgMagic)
+	b = append(b, dawgVersion)
+	b = appendString(b, g.lang.String())
+	b = append(b, g.hash[:]...)
+	b = binary.AppendUvarint(b, uint64(len(g.alphabet)))
+	for _, l := range g.alphabet {
+		b = appendString(b, l)
+	}
+	b = binary.AppendUvarint(b, uint64(len(g.edges)))
+
+	if _, err := bw.Write(b); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, g.edges); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// readDawg reads a graph written in its binary format.
+func readDawg(r io.Reader) (*dawg, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(dawgMagic)+1)
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, err
+	}
+	if string(magic[:len(dawgMagic)]) != dawgMagic {
+		return nil, errors.New("not a compiled dictionary")
+	}
+	if v := magic[len(dawgMagic)]; v != dawgVersion {
+		return nil, fmt.Errorf("unsupported compiled dictionary version: %d", v)
+	}
+	tag, err := readString(br)
+	if err != nil {
+		return nil, err
+	}
+	g := &dawg{
+		index: make(map[string]int),
+	}
+	if g.lang, err = language.Parse(tag); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(br, g.hash[:]); err != nil {
+		return nil, err
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxDawgLetters {
+		return nil, fmt.Errorf("invalid letter count: %d", n)
+	}
+	for i := 0; i < int(n); i++ {
+		l, err := readString(br)
+		if err != nil {
+			return nil, err
+		}
+		g.index[l] = i
+		g.alphabet = append(g.alphabet, l)
+	}
+	n, err = binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if n > edgeChildMask {
+		return nil, fmt.Errorf("invalid edge count: %d", n)
+	}
+	g.edges = make([]uint32, n)
+	if err := binary.Read(br, binary.LittleEndian, g.edges); err != nil {
+		return nil, err
+	}
+	// Ensure that the edges are consistent, so
+	// that walking the graph cannot go astray.
+	for _, e := range g.edges[min(rootNode, len(g.edges)):] {
+		if edgeLetter(e) >= len(g.alphabet) || int(edgeChild(e)) >= len(g.edges) {
+			return nil, errors.New("corrupted compiled dictionary")
+		}
+	}
+	return g, nil
+}
+
+func readString(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	if n > 1<<10 {
+		return "", fmt.Errorf("invalid string length: %d", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// compileDictionary builds the graph of the
+// words read from the dictionary.
+func compileDictionary(r io.Reader, d distribution) (*dawg, error) {
+	var words [][]string
+
+	skipped, err := scanWords(r, d, func(_ string, letters []string) {
+		words = append(words, letters)
+	})
+	if err != nil {
+		return nil, err
+	}
+	g, err := buildDawg(words, d.lang)
+	if err != nil {
+		return nil, err
+	}
+	g.skipped = skipped
+
+	return g, nil
+}
+
+func readDawgFile(path string) (*dawg, error) {
+	f, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return readDawg(f)
+}
+
+func writeDawgFile(path string, g *dawg) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := g.write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// isDawgFile returns whether the file
+// at the path is a compiled dictionary.
+func isDawgFile(path string) (bool, error) {
+	f, err := openFile(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	b := make([]byte, len(dawgMagic))
+
+	if _, err := io.ReadFull(f, b); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return bytes.Equal(b, []byte(dawgMagic)), nil
+}
+
+// hashDictionary returns the SHA-256 hash of the content of
+// the dictionary file, and of the policy of the distribution
+// used to read its words.
+func hashDictionary(path string, d distribution) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	f, err := openFile(path)
+	if err != nil {
+		return sum, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return sum, err
+	}
+	_, _ = io.WriteString(h, d.policy.String())
+	copy(sum[:], h.Sum(nil))
+
+	return sum, nil
+}
+
+// dawgCachePath returns the path of the compiled dictionary
+// cached next to the word list, which is keyed by the hash of
+// the word list, and the language of the distribution used to
+// split the words into letters.
+func dawgCachePath(path string, lang language.Tag, sum [sha256.Size]byte) string {
+	return fmt.Sprintf("%s.%s.%x.dawg", path, lang, sum[:8])
+}
+
+// hasDawgCache returns whether a compiled dictionary is
+// cached next to the word list for the language, for any
+// version of the word list.
+func hasDawgCache(path string, lang language.Tag) (bool, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return false, err
+	}
+	prefix := fmt.Sprintf("%s.%s.", filepath.Base(path), lang)
+
+	for _, e := range entries {
+		if n := e.Name(); strings.HasPrefix(n, prefix) && strings.HasSuffix(n, ".dawg") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadCompiledDictionary returns the graph of the dictionary
+// file if it is a compiled dictionary, or if its compiled
+// version is cached. Otherwise, it returns nil.
+func loadCompiledDictionary(path string, d distribution) (*dawg, error) {
+	if _, ok := d.builtinDict(path); ok {
+		return nil, nil
+	}
+	ok, err := isDawgFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		g, err := readDawgFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if d.lang != language.Und && g.lang != d.lang {
+			return nil, fmt.Errorf("dictionary compiled for language %q instead of %q", g.lang, d.lang)
+		}
+		return g, nil
+	}
+	// The standard input has no
+	// location to cache its graph.
+	if path == stdinPath {
+		return nil, nil
+	}
+	// The word list is only hashed to find
+	// its cache if it has been compiled.
+	ok, err = hasDawgCache(path, d.lang)
+	if err != nil || !ok {
+		return nil, err
+	}
+	sum, err := hashDictionary(path, d)
+	if err != nil {
+		return nil, err
+	}
+	cache := dawgCachePath(path, d.lang, sum)
+
+	if _, err := os.Stat(cache); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	g, err := readDawgFile(cache)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cached dictionary %q: %s", cache, err)
+	}
+	if g.hash != sum {
+		return nil, fmt.Errorf("cached dictionary %q doesn't match its source", cache)
+	}
+	return g, nil
+}
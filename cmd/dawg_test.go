@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_buildDawg(t *testing.T) {
+	words := "churro\ncurro\nchorro\nllorar\ncolorar\ncoro\nco\n"
+
+	g, err := compileDictionary(strings.NewReader(words), spanish)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range strings.Fields(strings.ToUpper(words)) {
+		if !g.contains(joinLetters(spanish.tokenize(w))) {
+			t.Errorf("expected graph to contain %s", w)
+		}
+	}
+	for _, tt := range []struct {
+		word     string
+		contains bool
+		prefix   bool
+	}{
+		{"[CH]U[RR]O", true, true},
+		{"[CH]U", false, true},
+		{"CHURRO", false, false},
+		{"COR", false, true},
+		{"CO", true, true},
+		{"C", false, true},
+		{"CURROS", false, false},
+		{"ZZZ", false, false},
+	} {
+		if got := g.contains(tt.word); got != tt.contains {
+			t.Errorf("contains(%q): got %t, want %t", tt.word, got, tt.contains)
+		}
+		if got := g.hasPrefix(tt.word); got != tt.prefix {
+			t.Errorf("hasPrefix(%q): got %t, want %t", tt.word, got, tt.prefix)
+		}
+	}
+}
+
+func Test_dawg_write(t *testing.T) {
+	g, err := compileDictionary(strings.NewReader("cat\nact\ncats\n"), english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.hash[0] = 42
+
+	var buf bytes.Buffer
+	if err := g.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rg, err := readDawg(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, rg) {
+		t.Errorf("expected graph read to be identical to the graph written")
+	}
+	if _, err := readDawg(strings.NewReader("cat\nact\n")); err == nil {
+		t.Errorf("expected error reading a word list")
+	}
+}
+
+func Test_dawg_findWords_french(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	path := filepath.Join(dictDir, "french/ods8.txt.gz")
+
+	r, err := openDictionaryFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := compileDictionary(bytes.NewReader(b), french)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict, err := parseDictionary(io.NopCloser(bytes.NewReader(b)), french, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The graph must find the same
+	// words as the indexed dictionary.
+	for _, draw := range []string{
+		"OCBSWYO",
+		"UOSERSP",
+		"PATTES?",
+		"PTTES??",
+		"XYZABCD",
+		"AEIERST",
+	} {
+		tiles := tilesFromWord(draw, french)
+
+		if got, want := g.findWords(tiles, french), dict.findWords(tiles, french); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got words %v, want %v", draw, got, want)
+		}
+		if got, want := g.findSubWords(tiles, french, 2), dict.findSubWords(tiles, french, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %d sub-words, want %d", draw, len(got), len(want))
+		}
+		if got, want := g.findWordsWithExtra(tiles, french), dict.findWordsWithExtra(tiles, french); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got words with extra letter %v, want %v", draw, got, want)
+		}
+	}
+}
+
+func Test_loadDictionaryFile_cached(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "words.txt")
+
+	if err := os.WriteFile(path, []byte("cat\nact\ncats\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Without a cache, the word list is parsed.
+	wf, err := loadDictionaryFile(path, english, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := wf.(*indexedDict); !ok {
+		t.Fatalf("expected a word list, got %T", wf)
+	}
+	if ok, err := hasDawgCache(path, english.lang); err != nil || ok {
+		t.Fatalf("expected no cache, got %t (%v)", ok, err)
+	}
+	sum, err := hashDictionary(path, english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := compileDictionary(strings.NewReader("cat\nact\ncats\n"), english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.hash = sum
+
+	cache := dawgCachePath(path, english.lang, sum)
+	if err := writeDawgFile(cache, g); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := hasDawgCache(path, english.lang); !ok {
+		t.Errorf("expected the cache to be found")
+	}
+	for _, p := range []string{path, cache} {
+		wf, err = loadDictionaryFile(p, english, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := wf.(*dawg); !ok {
+			t.Fatalf("%s: expected a compiled dictionary, got %T", p, wf)
+		}
+	}
+	// The cache is keyed by the content of the word list,
+	// and the compiled dictionary by its language.
+	if err := os.WriteFile(path, []byte("dog\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wf, err = loadDictionaryFile(path, english, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := wf.(*indexedDict); !ok {
+		t.Errorf("expected outdated cache to be ignored, got %T", wf)
+	}
+	if _, err := loadDictionaryFile(cache, french, 0); err == nil {
+		t.Errorf("expected error loading dictionary compiled for another language")
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// definitions maps the words of a dictionary,
+// normalized for a distribution, to their definition.
+type definitions map[string]string
+
+// loadDefinitionsFile loads the definitions file at the
+// given path, which is read as a dictionary file.
+func loadDefinitionsFile(path string, d distribution) (definitions, error) {
+	r, err := openDictionaryFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return parseDefinitions(r, d)
+}
+
+// parseDefinitions reads the definitions, one per line, where
+// a word and its definition are separated by a tab. The empty
+// lines, and the lines starting with a # are ignored. Several
+// definitions of the same word are joined.
+func parseDefinitions(r io.Reader, d distribution) (definitions, error) {
+	var (
+		defs      = make(definitions)
+		scan      = bufio.NewScanner(r)
+		normalize = d.normalizer()
+	)
+	for i := 1; scan.Scan(); i++ {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, def, ok := strings.Cut(line, "\t")
+		if !ok {
+			return nil, fmt.Errorf("missing tab separator at line %d", i)
+		}
+		word = normalize(strings.TrimSpace(word))
+		def = strings.TrimSpace(def)
+
+		if word == "" || def == "" {
+			return nil, fmt.Errorf("empty word or definition at line %d", i)
+		}
+		if prev, ok := defs[word]; ok {
+			def = prev + "; " + def
+		}
+		defs[word] = def
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseDefinitions(t *testing.T) {
+	const defs = "# Définitions\n" +
+		"été\tSaison la plus chaude de l'année\n" +
+		"\n" +
+		"zythum\tBière de l'Égypte ancienne\n" +
+		"ÉTÉ\tParticipe passé de être\n"
+
+	got, err := parseDefinitions(strings.NewReader(defs), french)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := definitions{
+		"ETE":    "Saison la plus chaude de l'année; Participe passé de être",
+		"ZYTHUM": "Bière de l'Égypte ancienne",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got definitions %q, want %q", got, want)
+	}
+	for _, s := range []string{
+		"zythum Bière\n",
+		"zythum\t\n",
+	} {
+		if _, err := parseDefinitions(strings.NewReader(s), french); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func Test_tui_selectScrabble(t *testing.T) {
+	ui := &tui{
+		game: &game{scrabbles: []string{"ACT", "CAT", "TAC"}},
+		defs: definitions{},
+	}
+	for _, tt := range []struct {
+		next     bool
+		selected string
+	}{
+		{false, "TAC"},
+		{true, "ACT"},
+		{true, "CAT"},
+		{false, "ACT"},
+	} {
+		ui.selectScrabble(tt.next)
+		if ui.selected != tt.selected {
+			t.Errorf("got selected word %s, want %s", ui.selected, tt.selected)
+		}
+	}
+	// The selection starts over once the
+	// words are found for a new draw.
+	ui.game.scrabbles = []string{"DOG", "GOD"}
+	ui.selectScrabble(true)
+
+	if ui.selected != "DOG" {
+		t.Errorf("got selected word %s, want DOG", ui.selected)
+	}
+}
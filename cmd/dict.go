@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+)
+
+var (
+	compileOutput string
+	checkLimit    int
+)
+
+var dictCmd = &cobra.Command{
+	Use:   "dict",
+	Short: "Manage dictionaries",
+}
+
+var dictCompileCmd = &cobra.Command{
+	Use:   "compile <file>",
+	Short: "Compile a dictionary into a word graph",
+	Long: "Compile a dictionary into a word graph (DAWG), which is faster to load\n" +
+		"and search than a word list.\n\n" +
+		"By default, the compiled dictionary is cached next to the word list,\n" +
+		"and is loaded instead of the word list as long as its content and the\n" +
+		"language of the distribution don't change.",
+	Args: cobra.ExactArgs(1),
+	RunE: runDictCompile,
+}
+
+func runDictCompile(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	path := args[0]
+	dn := cmd.Flag("distribution").Value.String()
+
+	d, err := lookupDistribution(dn)
+	if err != nil {
+		return err
+	}
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return err
+	}
+	d.policy = policy.apply(d.policy)
+
+	compiled, err := isDawgFile(path)
+	if err != nil {
+		return err
+	}
+	if compiled {
+		return fmt.Errorf("dictionary %q is already compiled", path)
+	}
+	if path == stdinPath && compileOutput == "" {
+		return fmt.Errorf("the output file is required to compile the standard input")
+	}
+	sum, err := hashDictionary(path, d)
+	if err != nil {
+		return err
+	}
+	r, err := openDictionaryFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	g, err := compileDictionary(r, d)
+	if err != nil {
+		return fmt.Errorf("failed to compile dictionary %q: %s", path, err)
+	}
+	g.hash = sum
+
+	out := compileOutput
+	if out == "" {
+		out = dawgCachePath(path, d.lang, sum)
+	}
+	if err := writeDawgFile(out, g); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "compiled %s to %s (%d letters, %d edges)\n",
+		path,
+		out,
+		len(g.alphabet),
+		len(g.edges),
+	)
+	if err == nil && g.skipped != 0 {
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "skipped %d words with letters outside of the distribution\n", g.skipped)
+	}
+	return err
+}
+
+var dictCheckCmd = &cobra.Command{
+	Use:   "check <file>",
+	Short: "Check a word list and print its statistics",
+	Long: "Check a word list before using it as a dictionary, and print its\n" +
+		"statistics.\n\n" +
+		"The words that contain characters other than letters, or letters that\n" +
+		"are not part of the distribution, are reported, as well as the empty\n" +
+		"lines, the duplicate words, regardless of their case, and the words\n" +
+		"written in mixed case. Capitalized words, such as German nouns, are\n" +
+		"only counted. The command fails if any issue is found.",
+	Args: cobra.ExactArgs(1),
+	RunE: runDictCheck,
+}
+
+func runDictCheck(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	path := args[0]
+	dn := cmd.Flag("distribution").Value.String()
+
+	d, err := lookupDistribution(dn)
+	if err != nil {
+		return err
+	}
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return err
+	}
+	d.policy = policy.apply(d.policy)
+
+	compiled, err := isDawgFile(path)
+	if err != nil {
+		return err
+	}
+	if compiled {
+		return fmt.Errorf("dictionary %q is compiled, check its word list instead", path)
+	}
+	r, err := openDictionaryFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	rep, err := checkDictionary(r, d)
+	if err != nil {
+		return fmt.Errorf("failed to check dictionary %q: %s", path, err)
+	}
+	if err := rep.write(cmd.OutOrStdout(), checkLimit); err != nil {
+		return err
+	}
+	if n := rep.issueCount(); n != 0 {
+		return fmt.Errorf("found %d issues in dictionary %q", n, path)
+	}
+	return nil
+}
+
+// dictIssue is an issue found on a line of a word list.
+type dictIssue struct {
+	line   int
+	word   string
+	detail string
+}
+
+// dictReport holds the issues and the statistics
+// of a word list checked against a distribution.
+type dictReport struct {
+	lines       int
+	words       int
+	capitalized int
+	lengths     map[int]int
+	empty       []dictIssue
+	invalid     []dictIssue
+	unknown     []dictIssue
+	duplicates  []dictIssue
+	mixedCase   []dictIssue
+}
+
+// checkDictionary reads the word list, one word per line,
+// and reports the words that cannot be used with the letters
+// of the distribution. The statistics are computed from the
+// distinct words, split into the letters of the distribution.
+func checkDictionary(r io.Reader, d distribution) (*dictReport, error) {
+	var (
+		rep = &dictReport{
+			lengths: make(map[int]int),
+		}
+		scan      = bufio.NewScanner(r)
+		normalize = d.normalizer()
+		upper     = cases.Upper(d.lang)
+		lower     = cases.Lower(d.lang)
+		title     = cases.Title(d.lang)
+		seen      = make(map[string]int)
+		alphabet  = d.alphabet()
+	)
+	scan.Split(bufio.ScanLines)
+
+	for i := 1; scan.Scan(); i++ {
+		line := scan.Text()
+		rep.lines++
+
+		if strings.TrimSpace(line) == "" {
+			rep.empty = append(rep.empty, dictIssue{line: i})
+			continue
+		}
+		// Some letters of a distribution contain
+		// punctuation, such as the Catalan L·L.
+		if j := strings.IndexFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !d.hasRune(r)
+		}); j != -1 {
+			r, _ := utf8.DecodeRuneInString(line[j:])
+			rep.invalid = append(rep.invalid, dictIssue{i, line,
+				fmt.Sprintf("'%c' is not a letter", r),
+			})
+			continue
+		}
+		capitalized := false
+		if u := upper.String(line); line != u && line != lower.String(line) {
+			if line == title.String(line) {
+				capitalized = true
+			} else {
+				rep.mixedCase = append(rep.mixedCase, dictIssue{i, line, "mixed case"})
+			}
+		}
+		w := normalize(line)
+
+		if first, ok := seen[w]; ok {
+			rep.duplicates = append(rep.duplicates, dictIssue{i, line,
+				fmt.Sprintf("duplicate of line %d", first),
+			})
+			continue
+		}
+		seen[w] = i
+
+		letters := d.tokenize(w)
+		if err := checkWord(letters, alphabet); err != nil {
+			rep.unknown = append(rep.unknown, dictIssue{i, line, err.Error()})
+			continue
+		}
+		rep.words++
+		rep.lengths[len(letters)]++
+
+		if capitalized {
+			rep.capitalized++
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
+
+func (rep *dictReport) issueCount() int {
+	return len(rep.empty) +
+		len(rep.invalid) +
+		len(rep.unknown) +
+		len(rep.duplicates) +
+		len(rep.mixedCase)
+}
+
+// write writes the report, with at most limit issues
+// listed for each kind. A limit of zero lists them all.
+func (rep *dictReport) write(w io.Writer, limit int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "%d lines, %d valid words (%d capitalized), %d issues\n",
+		rep.lines,
+		rep.words,
+		rep.capitalized,
+		rep.issueCount(),
+	)
+	for _, kind := range []struct {
+		name   string
+		issues []dictIssue
+	}{
+		{"empty lines", rep.empty},
+		{"invalid words", rep.invalid},
+		{"unknown letters", rep.unknown},
+		{"duplicates", rep.duplicates},
+		{"mixed case", rep.mixedCase},
+	} {
+		if len(kind.issues) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "\n%s: %d\n", kind.name, len(kind.issues))
+		for i, is := range kind.issues {
+			if limit > 0 && i == limit {
+				_, _ = fmt.Fprintf(tw, "  ... and %d more\n", len(kind.issues)-limit)
+				break
+			}
+			if is.word == "" {
+				_, _ = fmt.Fprintf(tw, "  line %d\n", is.line)
+			} else {
+				_, _ = fmt.Fprintf(tw, "  line %d\t%s\t%s\n", is.line, is.word, is.detail)
+			}
+		}
+	}
+	if rep.words != 0 {
+		lengths := make([]int, 0, len(rep.lengths))
+		for l := range rep.lengths {
+			lengths = append(lengths, l)
+		}
+		slices.Sort(lengths)
+
+		_, _ = fmt.Fprintf(tw, "\nLENGTH\tWORDS\tPERCENT\n")
+		for _, l := range lengths {
+			_, _ = fmt.Fprintf(tw, "%d\t%d\t%.1f%%\n",
+				l,
+				rep.lengths[l],
+				100*float64(rep.lengths[l])/float64(rep.words),
+			)
+		}
+	}
+	return tw.Flush()
+}
+
+func setupDictFlags() {
+	f := dictCompileCmd.Flags()
+	f.SortFlags = false
+
+	f.StringP("distribution", "l", "",
+		"letter distribution language",
+	)
+	f.StringVarP(&compileOutput, "output", "o", "",
+		"output file path (default cached next to the file)",
+	)
+	f = dictCheckCmd.Flags()
+	f.SortFlags = false
+
+	f.StringP("distribution", "l", "",
+		"letter distribution language",
+	)
+	f.IntVar(&checkLimit, "limit", 10,
+		"maximum number of issues listed per kind (0 for all)",
+	)
+	dictCmd.AddCommand(dictCompileCmd)
+	dictCmd.AddCommand(dictCheckCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_checkDictionary(t *testing.T) {
+	words := "chat\n\nchien\nCHAT\nniño\nMcDo\nNoël\nc'est\nchats\nParigo\n"
+
+	// Without folding, the accented letters
+	// are not part of the French distribution.
+	d := french
+	d.policy.fold = false
+
+	rep, err := checkDictionary(strings.NewReader(words), d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rep.lines, 10; got != want {
+		t.Errorf("got %d lines, want %d", got, want)
+	}
+	if got, want := rep.words, 5; got != want {
+		t.Errorf("got %d words, want %d", got, want)
+	}
+	if got, want := rep.capitalized, 1; got != want {
+		t.Errorf("got %d capitalized words, want %d", got, want)
+	}
+	for _, tt := range []struct {
+		kind   string
+		issues []dictIssue
+		want   []dictIssue
+	}{
+		{"empty", rep.empty, []dictIssue{{line: 2}}},
+		{"invalid", rep.invalid, []dictIssue{{8, "c'est", "''' is not a letter"}}},
+		{"unknown", rep.unknown, []dictIssue{
+			{5, "niño", "'Ñ' is not a letter of the distribution"},
+			{7, "Noël", "'Ë' is not a letter of the distribution"},
+		}},
+		{"duplicates", rep.duplicates, []dictIssue{{4, "CHAT", "duplicate of line 1"}}},
+		{"mixed case", rep.mixedCase, []dictIssue{{6, "McDo", "mixed case"}}},
+	} {
+		if !reflect.DeepEqual(tt.issues, tt.want) {
+			t.Errorf("%s: got issues %v, want %v", tt.kind, tt.issues, tt.want)
+		}
+	}
+	want := map[int]int{4: 2, 5: 2, 6: 1}
+	if !reflect.DeepEqual(rep.lengths, want) {
+		t.Errorf("got lengths %v, want %v", rep.lengths, want)
+	}
+	var buf bytes.Buffer
+	if err := rep.write(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "  ... and 1 more\n") {
+		t.Errorf("expected the issues to be limited, got\n%s", buf.String())
+	}
+}
@@ -2,24 +2,79 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
-	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
-type indexedDict map[string][]string
+// wordFinder finds the words of a dictionary
+// that can be formed with the tiles of a draw.
+type wordFinder interface {
+	findWords(tiles rack, d distribution) []string
+	findSubWords(tiles rack, d distribution, minLen int) []anagram
+	findWordsWithExtra(tiles rack, d distribution) []extraWords
+	contains(w string) bool
+}
+
+// wordSet validates the words formed on a board. The
+// words and prefixes are encoded with joinLetters.
+type wordSet interface {
+	contains(w string) bool
+	hasPrefix(p string) bool
+	match(p *pattern, visit func(letters []string))
+}
+
+// indexedDict indexes the words of a dictionary by their
+// letters, sorted and encoded. The sorted letters are also
+// stored in a word graph, which is walked to find the words
+// that can be formed with blank tiles, or with a subset of
+// the tiles, instead of looking up every combination.
+type indexedDict struct {
+	words map[string][]string
+	keys  *dawg
+	// skipped is the number of words of the dictionary
+	// with letters outside of the distribution.
+	skipped int
+}
+
+// lexicon is a sorted list of all the words of a dictionary,
+// regardless of their length, used to validate the words formed
+// on a board.
+type lexicon []string
+
+// contains returns whether the word is part of the lexicon.
+func (l lexicon) contains(w string) bool {
+	i := sort.SearchStrings(l, w)
+	return i < len(l) && l[i] == w
+}
+
+// hasPrefix returns whether at least one word
+// of the lexicon starts with the given prefix.
+func (l lexicon) hasPrefix(p string) bool {
+	i := sort.SearchStrings(l, p)
+	return i < len(l) && strings.HasPrefix(l[i], p)
+}
+
+// contains returns whether the word, whose letters
+// are encoded with joinLetters, is part of the dictionary.
+func (id *indexedDict) contains(w string) bool {
+	letters := splitLetters(w)
+	word := strings.Join(letters, "")
+
+	slices.Sort(letters)
+
+	return slices.Contains(id.words[joinLetters(letters)], word)
+}
 
-func (id indexedDict) findWords(tiles rack, d distribution) []string {
+func (id *indexedDict) findWords(tiles rack, d distribution) []string {
 	r := make([]string, 0, len(tiles))
 
 	blanks := 0
@@ -35,145 +90,375 @@ func (id indexedDict) findWords(tiles rack, d distribution) []string {
 	}
 	slices.Sort(r)
 
-	return id[strings.Join(r, "")]
+	return id.words[joinLetters(r)]
 }
 
-func (id indexedDict) findWordsWithBlanks(r []string, d distribution, n int) []string {
+// findWordsWithBlanks returns the words that can be formed
+// with all the letters plus n blank tiles, sorted.
+func (id *indexedDict) findWordsWithBlanks(r []string, _ distribution, n int) []string {
 	var words []string
 
-	s := make([]string, 0, len(r)+n)
-	for _, c := range combinationsWithReplacement(d.alphabet(), n) {
-		s = s[:0]
-		s = append(s, r...)
-		s = append(s, c...)
-		slices.Sort(s)
-
-		if w, ok := id[strings.Join(s, "")]; ok {
-			words = append(words, w...)
-		}
+	tiles := make(rack, 0, len(r)+n)
+	for _, l := range r {
+		tiles = append(tiles, tile{letter: letter{L: l}})
 	}
+	for i := 0; i < n; i++ {
+		tiles = append(tiles, tile{letter: letter{L: blank}})
+	}
+	id.keys.searchSorted(tiles, func(key []int, _ []bool) {
+		words = append(words, id.words[id.keys.keyOf(key)]...)
+	})
 	sort.Strings(words)
+
 	return words
 }
 
-func loadDictionaryFile(path string, tag language.Tag, wordLen int) (indexedDict, error) {
-	f, err := os.Open(path)
+// extraWords holds the words formed with
+// all the tiles of a draw plus an extra letter.
+type extraWords struct {
+	letter string
+	words  []string
+}
+
+// findWordsWithExtra returns the words that can be formed
+// with all the tiles plus exactly one extra letter of the
+// alphabet, such as a letter already placed on the board,
+// grouped by extra letter in the order of the alphabet.
+// The dictionary must be indexed for all word lengths.
+func (id *indexedDict) findWordsWithExtra(tiles rack, d distribution) []extraWords {
+	return wordsWithExtra(id, tiles, d)
+}
+
+func wordsWithExtra(wf wordFinder, tiles rack, d distribution) []extraWords {
+	var found []extraWords
+
+	r := make(rack, len(tiles), len(tiles)+1)
+	copy(r, tiles)
+
+	for _, l := range d.alphabet() {
+		words := wf.findWords(append(r, d.tile(letter{L: l})), d)
+		if len(words) != 0 {
+			found = append(found, extraWords{
+				letter: l,
+				words:  words,
+			})
+		}
+	}
+	return found
+}
+
+// anagram is a word that can be formed with
+// some or all the tiles of a draw.
+type anagram struct {
+	word   string
+	length int
+	score  int
+}
+
+// findSubWords returns all the words of at least minLen
+// letters that can be formed with the tiles, grouped by
+// length in decreasing order, and sorted by their raw
+// score, which is the sum of the points of the tiles used.
+// The dictionary must be indexed for all word lengths.
+func (id *indexedDict) findSubWords(tiles rack, d distribution, minLen int) []anagram {
+	scores := make(map[string]int)
+
+	// The same letters can be formed with or without
+	// the blank tiles, in which case the best raw score
+	// is kept.
+	id.keys.search(tiles, func(key []int, blank []bool) {
+		if len(key) < minLen {
+			return
+		}
+		score := 0
+		for i, l := range key {
+			if !blank[i] {
+				score += int(d.points(id.keys.alphabet[l]))
+			}
+		}
+		k := id.keys.keyOf(key)
+		if s, ok := scores[k]; !ok || s < score {
+			scores[k] = score
+		}
+	})
+	var found []anagram
+
+	for k, score := range scores {
+		n := len(splitLetters(k))
+		for _, w := range id.words[k] {
+			found = append(found, anagram{
+				word:   w,
+				length: n,
+				score:  score,
+			})
+		}
+	}
+	sortAnagrams(found)
+
+	return found
+}
+
+// sortAnagrams sorts the words by decreasing
+// length, and then by decreasing raw score.
+func sortAnagrams(found []anagram) {
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.length != b.length {
+			return a.length > b.length
+		}
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.word < b.word
+	})
+}
+
+// dictSource is a dictionary file given on the command
+// line, named either explicitly with the name=path syntax,
+// or after the base name of the file, without extensions.
+type dictSource struct {
+	name string
+	path string
+}
+
+// parseDictSources parses the values of the dictionary
+// flags, whose names must be unique.
+func parseDictSources(values []string) ([]dictSource, error) {
+	var sources []dictSource
+
+	for _, v := range values {
+		var src dictSource
+
+		name, path, ok := strings.Cut(v, "=")
+		if ok && name != "" && !strings.ContainsAny(name, `/\`) {
+			src = dictSource{name: name, path: path}
+		} else if v == stdinPath {
+			src = dictSource{name: "stdin", path: v}
+		} else {
+			base := filepath.Base(v)
+			if i := strings.IndexByte(base, '.'); i > 0 {
+				base = base[:i]
+			}
+			src = dictSource{name: base, path: v}
+		}
+		if src.path == "" {
+			return nil, fmt.Errorf("empty path for dictionary %q", src.name)
+		}
+		if slices.ContainsFunc(sources, func(s dictSource) bool { return s.name == src.name }) {
+			return nil, fmt.Errorf("duplicate dictionary name: %q", src.name)
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+// loadDictionaryFile loads the dictionary file, which is either
+// a word list, or a compiled dictionary. If the compiled version
+// of the word list is cached next to it, it is loaded instead.
+func loadDictionaryFile(path string, d distribution, wordLen int) (wordFinder, error) {
+	g, err := loadCompiledDictionary(path, d)
+	if err != nil {
+		return nil, err
+	}
+	if g != nil {
+		return g, nil
+	}
+	r, err := openDictionary(path, d)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = f.Close()
+		_ = r.Close()
 	}()
-	gzipped, err := isGzipCompressed(f)
+	dict, err := parseDictionary(r, d, wordLen)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := f.Seek(0, 0); err != nil {
+	return dict, nil
+}
+
+func loadLexiconFile(path string, d distribution) (wordSet, error) {
+	g, err := loadCompiledDictionary(path, d)
+	if err != nil {
+		return nil, err
+	}
+	if g != nil {
+		return g, nil
+	}
+	r, err := openDictionary(path, d)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	lex, err := parseLexicon(r, d)
+	if err != nil {
 		return nil, err
 	}
-	var reader io.ReadCloser = f
+	return lex, nil
+}
 
-	if gzipped {
-		reader, err = gzip.NewReader(f)
+// openDictionary opens the embedded word list of the
+// distribution named by the path, such as "twl06" for the
+// English distributions, or the dictionary file otherwise.
+func openDictionary(path string, d distribution) (io.ReadCloser, error) {
+	if b, ok := d.builtinDict(path); ok {
+		r, err := gzip.NewReader(bytes.NewReader(b))
 		if err != nil {
 			return nil, err
 		}
+		return r, nil
+	}
+	return openDictionaryFile(path)
+}
+
+// openDictionaryFile opens the file at the given path, or
+// the standard input if the path is "-", and returns a reader
+// that transparently decompresses its content if it is gzipped,
+// bzipped, xz or zstd compressed, or archived in a zip file.
+func openDictionaryFile(path string) (io.ReadCloser, error) {
+	f, err := openFile(path)
+	if err != nil {
+		return nil, err
 	}
-	d, err := parseDictionary(reader, tag, wordLen)
+	r, err := decompress(f)
 	if err != nil {
+		_ = f.Close()
 		return nil, err
 	}
-	return d, nil
+	return r, nil
 }
 
-func parseDictionary(r io.ReadCloser, tag language.Tag, wordLen int) (indexedDict, error) {
+func parseDictionary(r io.ReadCloser, d distribution, wordLen int) (*indexedDict, error) {
 	var (
-		dict  = make(indexedDict)
-		scan  = bufio.NewScanner(r)
-		caser = cases.Upper(tag)
+		words = make(map[string][]string)
+		keys  [][]string
 	)
-	scan.Split(bufio.ScanLines)
-
-	for i := 1; scan.Scan(); i++ {
-		line := scan.Text()
-
-		if err := checkWord(line, tag); err != nil {
-			return nil, fmt.Errorf("invalid word %q at line %d: %s", line, i, err)
-		}
-		if wordLen > 0 && utf8.RuneCountInString(line) != wordLen {
-			continue
+	skipped, err := scanWords(r, d, func(w string, l []string) {
+		if wordLen > 0 && len(l) != wordLen {
+			return
 		}
-		w := caser.String(line)
-		r := []rune(w)
-		slices.Sort(r)
+		slices.Sort(l)
 
-		s := string(r)
-		dict[s] = append(dict[s], w)
+		s := joinLetters(l)
+		if _, ok := words[s]; !ok {
+			keys = append(keys, l)
+		}
+		words[s] = append(words[s], w)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if err := scan.Err(); err != nil {
+	g, err := buildDawg(keys, d.lang)
+	if err != nil {
 		return nil, err
 	}
-	return dict, nil
+	return &indexedDict{
+		words:   words,
+		keys:    g,
+		skipped: skipped,
+	}, nil
 }
 
-// Port of Python3 eponymous function from itertools package.
-// https://docs.python.org/3/library/itertools.html#itertools.combinations_with_replacement
-func combinationsWithReplacement(s []string, r int) [][]string {
-	n := len(s)
-	if n == 0 || r == 0 {
-		return nil
-	}
-	indices := make([]int, r)
-	var combs [][]string
-	for {
-		c := make([]string, r)
-		for i, idx := range indices {
-			c[i] = s[idx]
-		}
-		combs = append(combs, c)
-
-		// Find the rightmost index that
-		// can be incremented.
-		i := r - 1
-		for ; i >= 0; i-- {
-			if indices[i] != n-1 {
-				break
-			}
+func parseLexicon(r io.Reader, d distribution) (lexicon, error) {
+	var lex lexicon
+
+	_, err := scanWords(r, d, func(_ string, l []string) {
+		lex = append(lex, joinLetters(l))
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(lex)
+
+	return slices.Compact(lex), nil
+}
+
+// scanWords reads the words of the dictionary, one per line,
+// and calls fn with each word normalized and split into the
+// letters of the distribution. The words with letters outside
+// of the alphabet are either skipped or rejected, depending on
+// the policy of the distribution, and the number of skipped
+// words is returned.
+func scanWords(r io.Reader, d distribution, fn func(w string, letters []string)) (int, error) {
+	var (
+		scan      = bufio.NewScanner(r)
+		normalize = d.normalizer()
+		alphabet  = d.alphabet()
+		skipped   int
+	)
+	scan.Split(bufio.ScanLines)
+
+	for i := 1; scan.Scan(); i++ {
+		line := scan.Text()
+
+		w := normalize(line)
+		letters := d.tokenize(w)
+		if len(letters) == 0 {
+			continue
 		}
-		// If no index can be incremented,
-		// we're done.
-		if i < 0 {
-			break
+		err := checkWord(letters, alphabet)
+		if err == nil && strings.IndexFunc(w, unicode.IsSpace) != -1 {
+			err = errors.New("words cannot contain spaces")
 		}
-		// Increment the index and set all
-		// following indices to the same value.
-		indices[i]++
-
-		for j := i + 1; j < r; j++ {
-			indices[j] = indices[i]
+		if err != nil {
+			if !d.policy.reject {
+				skipped++
+				continue
+			}
+			return skipped, fmt.Errorf("invalid word %q at line %d: %s", line, i, err)
 		}
+		fn(w, letters)
 	}
-	return combs
+	return skipped, scan.Err()
 }
 
-func isGzipCompressed(r io.ReadCloser) (bool, error) {
-	buf := make([]byte, 512)
+// skippedWords returns the number of words skipped when
+// the dictionary was read from a word list, or zero if it
+// was compiled beforehand.
+func skippedWords(wf wordFinder) int {
+	if id, ok := wf.(*indexedDict); ok {
+		return id.skipped
+	}
+	return 0
+}
 
-	if _, err := r.Read(buf); err != nil {
-		return false, err
+// joinLetters concatenates the letters into a string that
+// identifies them unambiguously. Digraphs are enclosed in
+// brackets, so that they cannot be mistaken for a sequence
+// of their individual runes.
+func joinLetters(letters []string) string {
+	var sb strings.Builder
+	for _, l := range letters {
+		sb.WriteString(encodeLetter(l))
 	}
-	fileType := http.DetectContentType(buf)
-	if fileType == "application/x-gzip" {
-		return true, nil
+	return sb.String()
+}
+
+func encodeLetter(l string) string {
+	if utf8.RuneCountInString(l) > 1 {
+		return "[" + l + "]"
 	}
-	return false, nil
+	return l
 }
 
-func checkWord(w string, _ language.Tag) error {
-	for _, r := range w {
-		if !unicode.IsLetter(r) {
-			return fmt.Errorf("'%c' is not a letter", r)
+// splitLetters is the inverse of joinLetters,
+// and returns the letters of the encoded string.
+func splitLetters(s string) []string {
+	letters := make([]string, 0, len(s))
+
+	for len(s) != 0 {
+		if s[0] == '[' {
+			if i := strings.IndexByte(s, ']'); i > 0 {
+				letters = append(letters, s[1:i])
+				s = s[i+1:]
+				continue
+			}
 		}
+		_, n := utf8.DecodeRuneInString(s)
+		letters = append(letters, s[:n])
+		s = s[n:]
 	}
-	return nil
+	return letters
 }
@@ -3,20 +3,25 @@ package cmd
 import (
 	"bufio"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
+	"unicode"
 	"unicode/utf8"
-
-	"golang.org/x/text/language"
 )
 
 const dictDir = "../dictionaries/"
 
-var cachedDict indexedDict
+var (
+	cachedDict     *indexedDict
+	cachedFullDict *indexedDict
+)
 
 func Test_loadDictionaryFile(t *testing.T) {
 	if testing.Short() {
@@ -28,6 +33,8 @@ func Test_loadDictionaryFile(t *testing.T) {
 	}
 	var paths []string
 
+	// The dictionaries are stored in directories
+	// named after the distribution of their words.
 	for _, e := range entries {
 		if e.IsDir() {
 			files, err := os.ReadDir(filepath.Join(dictDir, e.Name()))
@@ -46,10 +53,16 @@ func Test_loadDictionaryFile(t *testing.T) {
 		filename := filepath.Base(path)
 
 		t.Run(filename, func(t *testing.T) {
-			dict, err := loadDictionaryFile(path, language.Und, 7)
+			d := distributions[filepath.Base(filepath.Dir(path))]
+
+			wf, err := loadDictionaryFile(path, d, 7)
 			if err != nil {
 				t.Fatal(err)
 			}
+			dict, ok := wf.(*indexedDict)
+			if !ok {
+				t.Fatalf("expected a word list, got %T", wf)
+			}
 			f, err := os.Open(path)
 			if err != nil {
 				t.Fatal(err)
@@ -63,12 +76,12 @@ func Test_loadDictionaryFile(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			linesCount, err := wcl(r, 7)
+			linesCount, err := wcl(r, 7, d)
 			if err != nil {
 				t.Fatal(err)
 			}
 			var wordsCount uint
-			for _, v := range dict {
+			for _, v := range dict.words {
 				wordsCount += uint(len(v))
 			}
 			if linesCount != wordsCount {
@@ -78,6 +91,32 @@ func Test_loadDictionaryFile(t *testing.T) {
 	}
 }
 
+func Test_loadDictionaryFile_builtin(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{"twl06", false},
+		{"SOWPODS", true},
+	} {
+		wf, err := loadDictionaryFile(tt.name, english, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// ZO is only valid in the international word list.
+		if got := wf.contains("ZO"); got != tt.want {
+			t.Errorf("%s: got ZO valid %t, want %t", tt.name, got, tt.want)
+		}
+	}
+	// A path with an extension is always a file.
+	if _, err := loadDictionaryFile("twl06.txt", english, 2); err == nil {
+		t.Errorf("expected missing file error")
+	}
+	if _, err := loadDictionaryFile("twl06", french, 2); err == nil {
+		t.Errorf("expected missing file error for another language")
+	}
+}
+
 func Test_indexedDict_findWords_french(t *testing.T) {
 	dict := frenchDict(t)
 
@@ -210,6 +249,221 @@ func Test_indexedDict_findWordsWithBlanks_french(t *testing.T) {
 	}
 }
 
+func Test_indexedDict_findWords_digraphs(t *testing.T) {
+	words := "churro\ncurro\nchorro\nllorar\ncolorar\n"
+
+	dict, err := parseDictionary(io.NopCloser(strings.NewReader(words)), spanish, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		letters []string
+		words   []string
+	}{
+		{[]string{"RR", "CH", "O", "U"}, []string{"CHURRO"}},
+		{[]string{"R", "R", "C", "H", "O", "U"}, nil},
+		{[]string{"A", "R", "LL", "O", "R"}, nil},
+		{[]string{"O", "CH", "RR", "O"}, []string{"CHORRO"}},
+	} {
+		words := dict.findWords(tilesFromLetters(tt.letters, spanish), spanish)
+		if !reflect.DeepEqual(words, tt.words) {
+			t.Errorf("got words %q, want %q", words, tt.words)
+		}
+	}
+}
+
+func Test_indexedDict_contains(t *testing.T) {
+	words := "churro\ncurro\nact\ncat\n"
+
+	dict, err := parseDictionary(io.NopCloser(strings.NewReader(words)), spanish, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		letters  []string
+		contains bool
+	}{
+		{[]string{"CH", "U", "RR", "O"}, true},
+		{[]string{"C", "U", "RR", "O"}, true},
+		{[]string{"C", "A", "T"}, true},
+		{[]string{"T", "A", "C"}, false},
+		{[]string{"C", "H", "U", "RR", "O"}, false},
+	} {
+		if got := dict.contains(joinLetters(tt.letters)); got != tt.contains {
+			t.Errorf("%v: got %t, want %t", tt.letters, got, tt.contains)
+		}
+	}
+}
+
+func Test_parseDictSources(t *testing.T) {
+	sources, err := parseDictSources([]string{
+		"dicts/twl06.txt.gz",
+		"collins=dicts/sowpods.txt",
+		"./other=dicts/csw.txt",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dictSource{
+		{name: "twl06", path: "dicts/twl06.txt.gz"},
+		{name: "collins", path: "dicts/sowpods.txt"},
+		{name: "csw", path: "./other=dicts/csw.txt"},
+	}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("got sources %v, want %v", sources, want)
+	}
+	for _, values := range [][]string{
+		{"a/twl06.txt", "b/twl06.dawg"},
+		{"twl06="},
+	} {
+		if _, err := parseDictSources(values); err == nil {
+			t.Errorf("%q: expected an error", values)
+		}
+	}
+}
+
+func Test_indexedDict_findSubWords(t *testing.T) {
+	words := "cat\nact\nat\nta\ncats\nscat\ncast\nzoo\na\n"
+
+	dict, err := parseDictionary(io.NopCloser(strings.NewReader(words)), english, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		draw     string
+		anagrams []anagram
+	}{
+		{
+			"TAC",
+			[]anagram{
+				{"ACT", 3, 5},
+				{"CAT", 3, 5},
+				{"AT", 2, 2},
+				{"TA", 2, 2},
+			},
+		},
+		{
+			// The blank is played as a C or an S,
+			// and its letter scores zero points.
+			"TA?",
+			[]anagram{
+				{"ACT", 3, 2},
+				{"CAT", 3, 2},
+				{"AT", 2, 2},
+				{"TA", 2, 2},
+			},
+		},
+		{
+			"SCAT",
+			[]anagram{
+				{"CAST", 4, 6},
+				{"CATS", 4, 6},
+				{"SCAT", 4, 6},
+				{"ACT", 3, 5},
+				{"CAT", 3, 5},
+				{"AT", 2, 2},
+				{"TA", 2, 2},
+			},
+		},
+		{
+			"ZX",
+			nil,
+		},
+	} {
+		got := dict.findSubWords(tilesFromWord(tt.draw, english), english, 2)
+		if !reflect.DeepEqual(got, tt.anagrams) {
+			t.Errorf("%s: got anagrams %v, want %v", tt.draw, got, tt.anagrams)
+		}
+	}
+}
+
+func Test_indexedDict_findWordsWithExtra(t *testing.T) {
+	words := "cats\ncast\ncart\nscat\nact\ncoats\ntacos\ncoast\n"
+
+	dict, err := parseDictionary(io.NopCloser(strings.NewReader(words)), english, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		draw   string
+		extras []extraWords
+	}{
+		{
+			"TAC",
+			[]extraWords{
+				{"R", []string{"CART"}},
+				{"S", []string{"CATS", "CAST", "SCAT"}},
+			},
+		},
+		{
+			// The word formed with the blank and the
+			// extra letter is found for both letters.
+			"TC?S",
+			[]extraWords{
+				{"A", []string{"COAST", "COATS", "TACOS"}},
+				{"O", []string{"COAST", "COATS", "TACOS"}},
+			},
+		},
+		{
+			"XYZ",
+			nil,
+		},
+	} {
+		got := dict.findWordsWithExtra(tilesFromWord(tt.draw, english), english)
+		if !reflect.DeepEqual(got, tt.extras) {
+			t.Errorf("%s: got words %v, want %v", tt.draw, got, tt.extras)
+		}
+	}
+}
+
+func Test_indexedDict_blanks_reference(t *testing.T) {
+	d := frenchFullDict(t)
+
+	for _, draw := range []string{
+		"PATTES?",
+		"PTTES??",
+		"RSTLNE?",
+		"AEIO???",
+		"ZWXK??",
+		"?",
+	} {
+		tiles := tilesFromWord(strings.ReplaceAll(draw, "?", "*"), french)
+		for i := 0; i < strings.Count(draw, "?"); i++ {
+			tiles = append(tiles, tile{letter: letter{L: blank}})
+		}
+		var letters []string
+		for _, t := range tiles {
+			if t.L != blank {
+				letters = append(letters, t.L)
+			}
+		}
+		n := len(tiles) - len(letters)
+
+		got := d.findWordsWithBlanks(letters, french, n)
+		want := wordsWithBlanks(d, letters, french, n)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got words %v, want %v", draw, got, want)
+		}
+		gotSub := d.findSubWords(tiles, french, minWordLen)
+		wantSub := subWordsWithBlanks(d, tiles, french, minWordLen)
+		if !reflect.DeepEqual(gotSub, wantSub) {
+			t.Errorf("%s: got %d sub-words, want %d", draw, len(gotSub), len(wantSub))
+		}
+	}
+}
+
+func Test_eachSubset(t *testing.T) {
+	var subsets []string
+
+	eachSubset([]string{"A", "A", "B"}, 1, func(s []string) {
+		subsets = append(subsets, strings.Join(s, ""))
+	})
+	want := []string{"A", "AA", "AAB", "AB", "B"}
+	if !reflect.DeepEqual(subsets, want) {
+		t.Errorf("got subsets %v, want %v", subsets, want)
+	}
+}
+
 func Test_combinationsWithReplacement(t *testing.T) {
 	for _, tt := range []struct {
 		letters []string
@@ -266,20 +520,216 @@ func Test_combinationsWithReplacement(t *testing.T) {
 	}
 }
 
-func frenchDict(t *testing.T) indexedDict {
-	t.Helper()
+// The functions below are the previous implementation of the
+// lookup of words with blanks, which enumerates the letters that
+// the blanks can stand for. They are used as a reference by the
+// tests and the benchmarks of the walk of the indexed letters.
+
+// wordsWithBlanks looks up every combination of
+// letters that the blanks can stand for.
+func wordsWithBlanks(id *indexedDict, r []string, d distribution, n int) []string {
+	var words []string
+
+	s := make([]string, 0, len(r)+n)
+	for _, c := range combinationsWithReplacement(d.alphabet(), n) {
+		s = s[:0]
+		s = append(s, r...)
+		s = append(s, c...)
+		slices.Sort(s)
+
+		if w, ok := id.words[joinLetters(s)]; ok {
+			words = append(words, w...)
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+// subWordsWithBlanks looks up every subset of the
+// letters, completed by every combination of letters
+// that the blanks of the subset can stand for.
+func subWordsWithBlanks(id *indexedDict, tiles rack, d distribution, minLen int) []anagram {
+	var (
+		letters []string
+		blanks  int
+	)
+	for _, t := range tiles {
+		if t.L == blank {
+			blanks++
+		} else {
+			letters = append(letters, t.L)
+		}
+	}
+	slices.Sort(letters)
+
+	alphabet := d.alphabet()
+	scores := make(map[string]int)
+	s := make([]string, 0, len(tiles))
+
+	lookup := func(sub []string) {
+		for n := 0; n <= blanks; n++ {
+			if len(sub)+n < max(minLen, 1) {
+				continue
+			}
+			combs := [][]string{nil}
+			if n > 0 {
+				combs = combinationsWithReplacement(alphabet, n)
+			}
+			for _, c := range combs {
+				s = s[:0]
+				s = append(s, sub...)
+				s = append(s, c...)
+				slices.Sort(s)
+
+				k := joinLetters(s)
+				if _, ok := id.words[k]; !ok {
+					continue
+				}
+				score := rawScore(s, sub, d)
+				if v, ok := scores[k]; !ok || v < score {
+					scores[k] = score
+				}
+			}
+		}
+	}
+	// The words made of blanks only are formed
+	// with the empty subset of the letters.
+	lookup(nil)
+	eachSubset(letters, 0, lookup)
+
+	var found []anagram
+
+	for k, score := range scores {
+		for _, w := range id.words[k] {
+			found = append(found, anagram{
+				word:   w,
+				length: len(splitLetters(k)),
+				score:  score,
+			})
+		}
+	}
+	sortAnagrams(found)
+
+	return found
+}
+
+// eachSubset calls fn with every distinct subset of at
+// least minLen letters of the sorted slice. The subsets
+// are also sorted, and must not be retained by fn.
+func eachSubset(s []string, minLen int, fn func([]string)) {
+	sub := make([]string, 0, len(s))
 
-	if cachedDict != nil {
-		return cachedDict
+	var walk func(start int)
+	walk = func(start int) {
+		if len(sub) >= minLen && len(sub) > 0 {
+			fn(sub)
+		}
+		for i := start; i < len(s); i++ {
+			// Skip identical letters at the same
+			// position to avoid duplicate subsets.
+			if i > start && s[i] == s[i-1] {
+				continue
+			}
+			sub = append(sub, s[i])
+			walk(i + 1)
+			sub = sub[:len(sub)-1]
+		}
 	}
+	walk(0)
+}
+
+// rawScore returns the sum of the points of the letters
+// that are covered by the tiles of the draw, given without
+// its blanks. The other letters are played with blank tiles,
+// which are worth zero points.
+func rawScore(word, tiles []string, d distribution) int {
+	avail := make(map[string]int, len(tiles))
+	for _, l := range tiles {
+		avail[l]++
+	}
+	score := 0
+	for _, l := range word {
+		if avail[l] > 0 {
+			avail[l]--
+			score += int(d.points(l))
+		}
+	}
+	return score
+}
+
+// Port of Python3 eponymous function from itertools package.
+// https://docs.python.org/3/library/itertools.html#itertools.combinations_with_replacement
+func combinationsWithReplacement(s []string, r int) [][]string {
+	n := len(s)
+	if n == 0 || r == 0 {
+		return nil
+	}
+	indices := make([]int, r)
+	var combs [][]string
+	for {
+		c := make([]string, r)
+		for i, idx := range indices {
+			c[i] = s[idx]
+		}
+		combs = append(combs, c)
+
+		// Find the rightmost index that
+		// can be incremented.
+		i := r - 1
+		for ; i >= 0; i-- {
+			if indices[i] != n-1 {
+				break
+			}
+		}
+		// If no index can be incremented,
+		// we're done.
+		if i < 0 {
+			break
+		}
+		// Increment the index and set all
+		// following indices to the same value.
+		indices[i]++
+
+		for j := i + 1; j < r; j++ {
+			indices[j] = indices[i]
+		}
+	}
+	return combs
+}
+
+func frenchDict(tb testing.TB) *indexedDict {
+	tb.Helper()
+
+	if cachedDict == nil {
+		cachedDict = loadFrenchDict(tb, 7)
+	}
+	return cachedDict
+}
+
+// frenchFullDict returns the French dictionary
+// indexed for all word lengths.
+func frenchFullDict(tb testing.TB) *indexedDict {
+	tb.Helper()
+
+	if cachedFullDict == nil {
+		cachedFullDict = loadFrenchDict(tb, 0)
+	}
+	return cachedFullDict
+}
+
+func loadFrenchDict(tb testing.TB, wordLen int) *indexedDict {
+	tb.Helper()
+
 	path := filepath.Join(dictDir, "french/ods8.txt.gz")
 
-	dict, err := loadDictionaryFile(path, language.French, 7)
+	wf, err := loadDictionaryFile(path, french, wordLen)
 	if err != nil {
-		t.Fatal(err)
+		tb.Fatal(err)
+	}
+	dict, ok := wf.(*indexedDict)
+	if !ok {
+		tb.Fatalf("expected a word list, got %T", wf)
 	}
-	cachedDict = dict
-
 	return dict
 }
 
@@ -289,14 +739,29 @@ func tilesFromWord(word string, d distribution) rack {
 	for _, r := range word {
 		for _, v := range d.letters {
 			if v.L == string(r) {
-				tiles = append(tiles, tile{letter: v})
+				tiles = append(tiles, d.tile(v))
+			}
+		}
+	}
+	return tiles
+}
+
+func tilesFromLetters(letters []string, d distribution) rack {
+	tiles := make(rack, 0, len(letters))
+
+	for _, l := range letters {
+		for _, v := range d.letters {
+			if v.L == l {
+				tiles = append(tiles, d.tile(v))
 			}
 		}
 	}
 	return tiles
 }
 
-func wcl(r io.Reader, wordLen int) (uint, error) {
+// wcl counts the lines of wordLen runes that
+// are only made of runes of the distribution.
+func wcl(r io.Reader, wordLen int, d distribution) (uint, error) {
 	var (
 		c    uint
 		scan = bufio.NewScanner(r)
@@ -304,8 +769,13 @@ func wcl(r io.Reader, wordLen int) (uint, error) {
 	scan.Split(bufio.ScanLines)
 
 	for scan.Scan() {
-		line := scan.Bytes()
-		if wordLen > 0 && utf8.RuneCount(line) != wordLen {
+		line := scan.Text()
+		if wordLen > 0 && utf8.RuneCountInString(line) != wordLen {
+			continue
+		}
+		if strings.IndexFunc(line, func(r rune) bool {
+			return !d.hasRune(unicode.ToUpper(r))
+		}) != -1 {
 			continue
 		}
 		c++
@@ -315,3 +785,58 @@ func wcl(r io.Reader, wordLen int) (uint, error) {
 	}
 	return c, nil
 }
+
+func benchmarkBlanks(b *testing.B, fn func(d *indexedDict, r []string, n int) []string) {
+	d := frenchFullDict(b)
+	letters := []string{"E", "S", "T", "R", "A", "N", "I"}
+
+	for n := 1; n <= 4; n++ {
+		r := letters[:len(letters)-n]
+
+		b.Run(fmt.Sprintf("blanks=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fn(d, r, n)
+			}
+		})
+	}
+}
+
+func Benchmark_indexedDict_findWordsWithBlanks(b *testing.B) {
+	benchmarkBlanks(b, func(d *indexedDict, r []string, n int) []string {
+		return d.findWordsWithBlanks(r, french, n)
+	})
+}
+
+func Benchmark_wordsWithBlanks(b *testing.B) {
+	benchmarkBlanks(b, func(d *indexedDict, r []string, n int) []string {
+		return wordsWithBlanks(d, r, french, n)
+	})
+}
+
+func benchmarkSubWords(b *testing.B, fn func(d *indexedDict, tiles rack) []anagram) {
+	d := frenchFullDict(b)
+
+	for n := 0; n <= 3; n++ {
+		tiles := tilesFromWord("ESTRANI"[:7-n], french)
+		for i := 0; i < n; i++ {
+			tiles = append(tiles, tile{letter: letter{L: blank}})
+		}
+		b.Run(fmt.Sprintf("blanks=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fn(d, tiles)
+			}
+		})
+	}
+}
+
+func Benchmark_indexedDict_findSubWords(b *testing.B) {
+	benchmarkSubWords(b, func(d *indexedDict, tiles rack) []anagram {
+		return d.findSubWords(tiles, french, minWordLen)
+	})
+}
+
+func Benchmark_subWordsWithBlanks(b *testing.B) {
+	benchmarkSubWords(b, func(d *indexedDict, tiles rack) []anagram {
+		return subWordsWithBlanks(d, tiles, french, minWordLen)
+	})
+}
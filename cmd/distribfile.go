@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/yaml.v3"
+)
+
+// distributionFile is the definition of a distribution
+// in a JSON, YAML or TOML file.
+type distributionFile struct {
+	Key         string       `json:"key" yaml:"key" toml:"key"`
+	Lang        string       `json:"lang" yaml:"lang" toml:"lang"`
+	Name        string       `json:"name" yaml:"name" toml:"name"`
+	Dictionary  string       `json:"dictionary" yaml:"dictionary" toml:"dictionary"`
+	Letters     []letterFile `json:"letters" yaml:"letters" toml:"letters"`
+	Vowels      []string     `json:"vowels" yaml:"vowels" toml:"vowels"`
+	BlankVowel  bool         `json:"blankVowel" yaml:"blankVowel" toml:"blankVowel"`
+	FoldAccents bool         `json:"foldAccents" yaml:"foldAccents" toml:"foldAccents"`
+	TileCount   int          `json:"tileCount" yaml:"tileCount" toml:"tileCount"`
+	// WordLength is the default number of tiles of the
+	// draws, and the bounds default to it, if defined.
+	WordLength    int `json:"wordLength" yaml:"wordLength" toml:"wordLength"`
+	MinWordLength int `json:"minWordLength" yaml:"minWordLength" toml:"minWordLength"`
+	MaxWordLength int `json:"maxWordLength" yaml:"maxWordLength" toml:"maxWordLength"`
+}
+
+type letterFile struct {
+	Letter    string `json:"letter" yaml:"letter" toml:"letter"`
+	Frequency uint   `json:"frequency" yaml:"frequency" toml:"frequency"`
+	Points    uint   `json:"points" yaml:"points" toml:"points"`
+}
+
+// distributionsDir returns the directory from which the
+// distribution files are loaded at startup, if it exists.
+var distributionsDir = func() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "scrabbler", "distributions")
+}
+
+// distribFileErrors holds the errors of the distribution files,
+// by key of distribution. They are reported by the verification,
+// and only fail the commands that use these distributions.
+var distribFileErrors = make(map[string]error)
+
+// loadUserDistributions registers the distributions of the
+// files, and of the files of the configuration directory,
+// next to the built-in distributions. The invalid files are
+// recorded in distribFileErrors instead.
+func loadUserDistributions(files []string) error {
+	if dir := distributionsDir(); dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("cannot read distributions directory: %s", err)
+		}
+		var dirFiles []string
+		for _, e := range entries {
+			if !e.IsDir() && distributionFormat(e.Name()) != "" {
+				dirFiles = append(dirFiles, filepath.Join(dir, e.Name()))
+			}
+		}
+		files = append(dirFiles, files...)
+	}
+	for _, path := range files {
+		key, d, err := loadDistributionFile(path)
+		if err != nil {
+			distribFileErrors[key] = fmt.Errorf("invalid distribution file %q: %s", path, err)
+			continue
+		}
+		if _, ok := distributions[key]; ok {
+			distribFileErrors[key] = fmt.Errorf("invalid distribution file %q: distribution %q already exists", path, key)
+			continue
+		}
+		if err := d.validate(); err != nil {
+			distribFileErrors[key] = fmt.Errorf("invalid distribution file %q: %s",
+				path, strings.ReplaceAll(err.Error(), "\n", "; "),
+			)
+		}
+		distributions[key] = d
+	}
+	return nil
+}
+
+// distributionFormat returns the format of the
+// distribution file, according to its extension.
+func distributionFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	return ""
+}
+
+// loadDistributionFile returns the distribution of the file,
+// and its key, which defaults to the name of the file without
+// extension, and which is also returned with the errors. The
+// path of the dictionary of the distribution is relative to the
+// directory of the file.
+func loadDistributionFile(path string) (string, distribution, error) {
+	b, err := os.ReadFile(path)
+	// The key defaults to the name of the file, to
+	// report the errors of the file by distribution.
+	key := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err != nil {
+		return key, distribution{}, err
+	}
+	var df distributionFile
+
+	switch distributionFormat(path) {
+	case "json":
+		err = json.Unmarshal(b, &df)
+	case "yaml":
+		err = yaml.Unmarshal(b, &df)
+	case "toml":
+		err = toml.Unmarshal(b, &df)
+	default:
+		return key, distribution{}, errors.New("unknown format, expected .json, .yaml or .toml file")
+	}
+	if err != nil {
+		return key, distribution{}, err
+	}
+	if df.Key == "" {
+		df.Key = key
+	}
+	if df.Dictionary != "" && !filepath.IsAbs(df.Dictionary) {
+		df.Dictionary = filepath.Join(filepath.Dir(path), df.Dictionary)
+	}
+	d, err := df.distribution()
+	if err != nil {
+		return df.Key, distribution{}, err
+	}
+	return df.Key, d, nil
+}
+
+// distribution returns the distribution of the file. The
+// letters are normalized as the words of the dictionaries.
+func (df distributionFile) distribution() (distribution, error) {
+	if df.Lang == "" {
+		return distribution{}, errors.New("missing language tag")
+	}
+	lang, err := language.Parse(df.Lang)
+	if err != nil {
+		return distribution{}, fmt.Errorf("invalid language tag %q: %s", df.Lang, err)
+	}
+	if len(df.Letters) == 0 {
+		return distribution{}, errors.New("no letters")
+	}
+	d := distribution{
+		lang:       lang,
+		name:       df.Name,
+		dictPath:   df.Dictionary,
+		blankVowel: df.BlankVowel,
+		tileCount:  df.TileCount,
+		policy:     wordPolicy{fold: df.FoldAccents},
+	}
+	if d.name == "" {
+		d.name = df.Key
+	}
+	caser := cases.Upper(lang)
+	normalize := func(l string) string {
+		return caser.String(norm.NFC.String(strings.TrimSpace(l)))
+	}
+	for _, l := range df.Letters {
+		L := normalize(l.Letter)
+		if L == "" {
+			return distribution{}, errors.New("empty letter")
+		}
+		d.letters = append(d.letters, letter{
+			L:         L,
+			frequency: l.Frequency,
+			points:    l.Points,
+		})
+	}
+	for _, v := range df.Vowels {
+		v = normalize(v)
+		if !slices.ContainsFunc(d.letters, func(l letter) bool { return l.L == v }) {
+			return distribution{}, fmt.Errorf("vowel %q is not a letter of the distribution", v)
+		}
+		d.vowels = append(d.vowels, v)
+	}
+	if d.tileCount == 0 {
+		for _, l := range d.letters {
+			d.tileCount += int(l.frequency)
+		}
+	}
+	if df.WordLength != 0 || df.MinWordLength != 0 || df.MaxWordLength != 0 {
+		wl := wordLengths{
+			min: df.MinWordLength,
+			max: df.MaxWordLength,
+			def: df.WordLength,
+		}
+		if wl.def == 0 {
+			wl.def = wl.min
+		}
+		if wl.min == 0 {
+			wl.min = wl.def
+		}
+		if wl.max == 0 {
+			wl.max = wl.def
+		}
+		d.lengths = wl
+	}
+	return d, nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func Test_loadDistributionFile(t *testing.T) {
+	want := distribution{
+		lang: language.Italian,
+		name: "Mini",
+		letters: []letter{
+			{blank, 1, 0},
+			{"A", 3, 1},
+			{"CH", 1, 5},
+			{"È", 2, 2},
+		},
+		vowels:    []string{"A", "È"},
+		tileCount: 7,
+		policy:    wordPolicy{fold: true},
+		lengths:   wordLengths{min: 5, max: 6, def: 5},
+	}
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"mini.json": `{
+			"lang": "it",
+			"name": "Mini",
+			"foldAccents": true,
+			"wordLength": 5,
+			"maxWordLength": 6,
+			"letters": [
+				{"letter": "?", "frequency": 1},
+				{"letter": "a", "frequency": 3, "points": 1},
+				{"letter": "ch", "frequency": 1, "points": 5},
+				{"letter": "è", "frequency": 2, "points": 2}
+			],
+			"vowels": ["a", "è"]
+		}`,
+		"mini.yaml": "lang: it\n" +
+			"name: Mini\n" +
+			"foldAccents: true\n" +
+			"wordLength: 5\n" +
+			"maxWordLength: 6\n" +
+			"letters:\n" +
+			"  - {letter: '?', frequency: 1}\n" +
+			"  - {letter: a, frequency: 3, points: 1}\n" +
+			"  - {letter: ch, frequency: 1, points: 5}\n" +
+			"  - {letter: è, frequency: 2, points: 2}\n" +
+			"vowels: [a, è]\n",
+		"mini.toml": "lang = \"it\"\n" +
+			"name = \"Mini\"\n" +
+			"foldAccents = true\n" +
+			"wordLength = 5\n" +
+			"maxWordLength = 6\n" +
+			"vowels = [\"a\", \"è\"]\n" +
+			"letters = [\n" +
+			"  {letter = \"?\", frequency = 1},\n" +
+			"  {letter = \"a\", frequency = 3, points = 1},\n" +
+			"  {letter = \"ch\", frequency = 1, points = 5},\n" +
+			"  {letter = \"è\", frequency = 2, points = 2},\n" +
+			"]\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		key, d, err := loadDistributionFile(path)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if key != "mini" {
+			t.Errorf("%s: got key %q, want mini", name, key)
+		}
+		if !reflect.DeepEqual(d, want) {
+			t.Errorf("%s: got distribution %+v, want %+v", name, d, want)
+		}
+	}
+}
+
+func Test_loadDistributionFile_errors(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"lang.json":   `{"letters": [{"letter": "A", "frequency": 1}]}`,
+		"tag.json":    `{"lang": "not a tag", "letters": [{"letter": "A", "frequency": 1}]}`,
+		"empty.json":  `{"lang": "en"}`,
+		"vowels.json": `{"lang": "en", "letters": [{"letter": "B", "frequency": 1}], "vowels": ["A"]}`,
+		"mini.xml":    `<distribution/>`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := loadDistributionFile(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func Test_loadUserDistributions(t *testing.T) {
+	dir := t.TempDir()
+
+	defer func(f func() string) { distributionsDir = f }(distributionsDir)
+	distributionsDir = func() string { return dir }
+
+	files := map[string]string{
+		"mini.json":    `{"lang": "en", "letters": [{"letter": "A", "frequency": 1}]}`,
+		"notes.txt":    `not a distribution`,
+		"english.yaml": "lang: en\nletters: [{letter: A, frequency: 1}]\n",
+		"broken.json":  `{"lang": "en", "tileCount": 2, "letters": [{"letter": "A", "frequency": 1}]}`,
+		"syntax.toml":  `lang = `,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer delete(distributions, "mini")
+	defer delete(distributions, "broken")
+	defer func() { distribFileErrors = make(map[string]error) }()
+
+	// The english distribution is built-in, and the file
+	// only fails the commands that use this distribution.
+	if err := loadUserDistributions(nil); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := lookupDistribution("mini"); err != nil || d.name != "mini" {
+		t.Errorf("expected the mini distribution to be registered: %v", err)
+	}
+	if _, err := lookupDistribution("english"); err == nil {
+		t.Error("expected an error for the english distribution")
+	}
+	for _, k := range []string{"broken", "syntax"} {
+		if _, err := lookupDistribution(k); err == nil {
+			t.Errorf("expected an error for the %s distribution", k)
+		}
+	}
+	if _, err := lookupDistribution("french"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -3,12 +3,21 @@ package cmd
 import (
 	"bytes"
 	"compress/gzip"
+	"errors"
+	"fmt"
 	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/language"
 
 	en "github.com/wI2L/scrabbler/dictionaries/english"
 	fr "github.com/wI2L/scrabbler/dictionaries/french"
+	de "github.com/wI2L/scrabbler/dictionaries/german"
+	it "github.com/wI2L/scrabbler/dictionaries/italian"
+	ro "github.com/wI2L/scrabbler/dictionaries/romanian"
 )
 
 const blank = "?"
@@ -24,15 +33,113 @@ type letter struct {
 
 // distribution maps the letters of a Scrabble game for
 // a particular language to their frequency and points.
+// The letters that aren't part of the vowels are consonants.
+// The blank tile is counted as a consonant, unless the
+// blankVowel flag is set. The policy defines how the words
+// are normalized, and whether accents are stripped when the
+// accented letters are not tiles of the distribution. The
+// dictionary is either embedded, or read from a file for the
+// distributions defined by the user. The other embedded word
+// lists of the language are named in builtins. The word lengths
+// are those of the standard game, unless defined by a variant.
 type distribution struct {
-	lang      language.Tag
-	name      string
-	dict      []byte
-	letters   []letter
-	tileCount int
+	lang       language.Tag
+	name       string
+	dict       []byte
+	dictPath   string
+	builtins   map[string][]byte
+	letters    []letter
+	vowels     []string
+	blankVowel bool
+	tileCount  int
+	policy     wordPolicy
+	lengths    wordLengths
 }
 
-func (d distribution) dictionary(wordLen int) (indexedDict, error) {
+// wordLengths defines the numbers of tiles
+// of the draws, and the default one.
+type wordLengths struct {
+	min, max, def int
+}
+
+// standardLengths are the word lengths of the
+// distributions that do not define their own. The
+// draws have at least as many tiles as the shortest
+// words of the insights, and at most as many as the
+// squares of a row of the board.
+var standardLengths = wordLengths{min: minWordLen, max: boardSize, def: 7}
+
+func (wl wordLengths) String() string {
+	switch {
+	case wl.min == wl.max:
+		return strconv.Itoa(wl.min)
+	case wl.min+1 == wl.max:
+		return fmt.Sprintf("%d or %d", wl.min, wl.max)
+	}
+	return fmt.Sprintf("between %d and %d", wl.min, wl.max)
+}
+
+// wordLengths returns the word lengths of the distribution.
+func (d distribution) wordLengths() wordLengths {
+	if d.lengths == (wordLengths{}) {
+		return standardLengths
+	}
+	return d.lengths
+}
+
+// wordLength returns the number of tiles to draw, which
+// defaults to that of the distribution if n is zero.
+func (d distribution) wordLength(n int) (int, error) {
+	wl := d.wordLengths()
+	if n == 0 {
+		return wl.def, nil
+	}
+	if n < wl.min || n > wl.max {
+		return 0, fmt.Errorf("word length must be %s", wl)
+	}
+	return n, nil
+}
+
+// hasDictionary returns whether the distribution
+// has a dictionary to find the words of the draws.
+func (d distribution) hasDictionary() bool {
+	return d.dict != nil || d.dictPath != ""
+}
+
+// builtinDict returns the embedded word list of the
+// distribution named by the path of a dictionary, which
+// has neither a directory nor an extension.
+func (d distribution) builtinDict(path string) ([]byte, bool) {
+	if strings.ContainsAny(path, `./\`) {
+		return nil, false
+	}
+	b, ok := d.builtins[strings.ToLower(path)]
+
+	return b, ok && b != nil
+}
+
+func (d distribution) dictionary(wordLen int) (wordFinder, error) {
+	if d.dictPath != "" {
+		return loadDictionaryFile(d.dictPath, d, wordLen)
+	}
+	if d.dict == nil {
+		return nil, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(d.dict))
+	if err != nil {
+		return nil, err
+	}
+	dict, err := parseDictionary(r, d, wordLen)
+	if err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+func (d distribution) lexicon() (wordSet, error) {
+	if d.dictPath != "" {
+		return loadLexiconFile(d.dictPath, d)
+	}
 	if d.dict == nil {
 		return nil, nil
 	}
@@ -40,7 +147,96 @@ func (d distribution) dictionary(wordLen int) (indexedDict, error) {
 	if err != nil {
 		return nil, err
 	}
-	return parseDictionary(r, d.lang, wordLen)
+	lex, err := parseLexicon(r, d)
+	if err != nil {
+		return nil, err
+	}
+	return lex, nil
+}
+
+// points returns the points of the given letter.
+func (d distribution) points(l string) uint {
+	for _, v := range d.letters {
+		if v.L == l {
+			return v.points
+		}
+	}
+	return 0
+}
+
+// kind returns the kind of the given letter.
+// If the distribution doesn't define its vowels,
+// the letter is classified using its base Latin
+// character, without diacritics.
+func (d distribution) kind(l string) letterKind {
+	if l == blank {
+		if d.blankVowel {
+			return kindVowel
+		}
+		return kindConsonant
+	}
+	if d.vowels == nil {
+		return latinKind(l)
+	}
+	if slices.Contains(d.vowels, l) {
+		return kindVowel
+	}
+	return kindConsonant
+}
+
+// tile returns a new tile for the given letter,
+// classified by kind according to the distribution.
+func (d distribution) tile(l letter) tile {
+	return tile{
+		letter: l,
+		vowel:  d.kind(l.L) == kindVowel,
+	}
+}
+
+// tokenize splits the word into the letters of the distribution.
+// The longest letters are matched first, so that a digraph takes
+// precedence over the sequence of its individual runes. Whitespaces
+// can be used to separate letters explicitly, and the runes that do
+// not belong to any letter are returned as is.
+func (d distribution) tokenize(w string) []string {
+	var digraphs []string
+	for _, v := range d.letters {
+		if utf8.RuneCountInString(v.L) > 1 {
+			digraphs = append(digraphs, v.L)
+		}
+	}
+	slices.SortFunc(digraphs, func(a, b string) int {
+		return utf8.RuneCountInString(b) - utf8.RuneCountInString(a)
+	})
+	letters := make([]string, 0, len(w))
+
+L:
+	for i := 0; i < len(w); {
+		for _, dg := range digraphs {
+			if strings.HasPrefix(w[i:], dg) {
+				letters = append(letters, dg)
+				i += len(dg)
+				continue L
+			}
+		}
+		r, n := utf8.DecodeRuneInString(w[i:])
+		if !unicode.IsSpace(r) {
+			letters = append(letters, w[i:i+n])
+		}
+		i += n
+	}
+	return letters
+}
+
+// hasRune returns whether the rune is part of
+// at least one letter of the distribution.
+func (d distribution) hasRune(r rune) bool {
+	for _, v := range d.letters {
+		if strings.ContainsRune(v.L, r) {
+			return true
+		}
+	}
+	return false
 }
 
 func (d distribution) alphabet() []string {
@@ -56,6 +252,57 @@ func (d distribution) alphabet() []string {
 	return a
 }
 
+// validate returns the inconsistencies of the distribution:
+// the tile count must be the sum of the frequencies of the
+// letters, which must be unique and have tiles, and the blank
+// tiles must be worth zero points. The vowels must be letters
+// of the distribution, and the default word length must be
+// within the bounds of the draws, without exceeding the
+// number of tiles. The bounds must be within those of the
+// standard word lengths.
+func (d distribution) validate() error {
+	var (
+		errs  []error
+		count int
+		seen  = make(map[string]bool)
+	)
+	for _, v := range d.letters {
+		switch {
+		case v.L == "":
+			errs = append(errs, errors.New("empty letter"))
+		case seen[v.L]:
+			errs = append(errs, fmt.Errorf("duplicate letter %q", v.L))
+		case v.frequency == 0:
+			errs = append(errs, fmt.Errorf("letter %q has no tiles", v.L))
+		case v.L == blank && v.points != 0:
+			errs = append(errs, fmt.Errorf("blank tiles are worth %d points instead of zero", v.points))
+		}
+		seen[v.L] = true
+		count += int(v.frequency)
+	}
+	if count != d.tileCount {
+		errs = append(errs, fmt.Errorf("tile count is %d, but the letters have %d tiles", d.tileCount, count))
+	}
+	for i, v := range d.vowels {
+		if !seen[v] || v == blank {
+			errs = append(errs, fmt.Errorf("vowel %q is not a letter", v))
+		} else if slices.Contains(d.vowels[:i], v) {
+			errs = append(errs, fmt.Errorf("duplicate vowel %q", v))
+		}
+	}
+	// The standard word lengths apply to any
+	// distribution, unless defined by a variant.
+	if wl := d.lengths; wl != (wordLengths{}) {
+		switch {
+		case wl.min < minWordLen || wl.max > boardSize || wl.min > wl.max || wl.def < wl.min || wl.def > wl.max:
+			errs = append(errs, fmt.Errorf("invalid word lengths: %d to %d, default %d", wl.min, wl.max, wl.def))
+		case wl.def > count:
+			errs = append(errs, fmt.Errorf("default word length %d exceeds the %d tiles", wl.def, count))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // french represents the distribution of letters for the
 // French edition. It contains 102 tiles.
 // https://en.wikipedia.org/wiki/Scrabble_letter_distributions#French
@@ -71,9 +318,10 @@ func (d distribution) alphabet() []string {
 // | 10 | K W X Y Z |         |     |    |             |    |    |     |
 // +----+-----------+---------+-----+----+-------------+----+----+-----+
 var french = distribution{
-	lang: language.French,
-	name: "Français",
-	dict: fr.ODS8,
+	lang:   language.French,
+	name:   "Français",
+	dict:   fr.ODS8,
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 9, 1},
@@ -103,9 +351,17 @@ var french = distribution{
 		{"Y", 1, 10},
 		{"Z", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 102,
 }
 
+// englishDicts are the embedded English word lists, the
+// international SOWPODS and the North American TWL06.
+var englishDicts = map[string][]byte{
+	"sowpods": en.SOWPODS,
+	"twl06":   en.TWL06,
+}
+
 // english represents the distribution of letters for the
 // standard English edition. It contains 100 tiles.
 // https://en.wikipedia.org/wiki/Scrabble_letter_distributions#English
@@ -122,9 +378,11 @@ var french = distribution{
 // | 10 | Q Z |           |    |       |       |    |     |     |
 // +----+-----+-----------+----+-------+-------+----+-----+-----+
 var english = distribution{
-	lang: language.English,
-	name: "English",
-	dict: en.SOWPODS,
+	lang:     language.English,
+	name:     "English",
+	dict:     en.SOWPODS,
+	builtins: englishDicts,
+	policy:   wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 9, 1},
@@ -154,6 +412,7 @@ var english = distribution{
 		{"Y", 2, 4},
 		{"Z", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 100,
 }
 
@@ -175,6 +434,7 @@ var english = distribution{
 var german = distribution{
 	lang: language.German,
 	name: "Deutsch",
+	dict: de.Hippler,
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 5, 1},
@@ -207,6 +467,7 @@ var german = distribution{
 		{"Ö", 1, 8},
 		{"Ü", 1, 6},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Ä", "Ö", "Ü"},
 	tileCount: 102,
 }
 
@@ -225,8 +486,10 @@ var german = distribution{
 // | 10 | Q  |         |           |         |         |     |     |     |     |
 // +----+----+---------+-----------+---------+---------+-----+-----+-----+-----+
 var italian = distribution{
-	lang: language.Italian,
-	name: "Italiano",
+	lang:   language.Italian,
+	name:   "Italiano",
+	dict:   it.ListeDiParole,
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 14, 1},
@@ -251,6 +514,7 @@ var italian = distribution{
 		{"V", 3, 5},
 		{"Z", 2, 8},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
 	tileCount: 120,
 }
 
@@ -271,8 +535,9 @@ var italian = distribution{
 // +----+-----+-----------+---------+----+---------+-----+-----+-----+
 
 var dutch = distribution{
-	lang: language.Dutch,
-	name: "Nederlands",
+	lang:   language.Dutch,
+	name:   "Nederlands",
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 6, 1},
@@ -302,6 +567,7 @@ var dutch = distribution{
 		{"Y", 1, 8},
 		{"Z", 2, 4},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 102,
 }
 
@@ -367,6 +633,7 @@ var czech = distribution{
 		{"Ů", 1, 4},
 		{"Ž", 1, 4},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Á", "É", "Í", "Ó", "Ú", "Ý", "Ě", "Ů"},
 	tileCount: 100,
 }
 
@@ -425,6 +692,7 @@ var icelandic = distribution{
 		{"Ý", 1, 9},
 		{"Þ", 1, 5},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Á", "É", "Í", "Ó", "Ö", "Ú", "Ý", "Æ"},
 	tileCount: 104,
 }
 
@@ -482,6 +750,7 @@ var krafla = distribution{
 		{"Ý", 1, 5},
 		{"Þ", 1, 7},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Á", "É", "Í", "Ó", "Ö", "Ú", "Ý", "Æ"},
 	tileCount: 100,
 }
 
@@ -501,8 +770,9 @@ var krafla = distribution{
 // | 10 | J   |         |     |    |           |     |    |     |
 // +----+-----+---------+-----+----+-----------+-----+----+-----+
 var afrikaans = distribution{
-	lang: language.Afrikaans,
-	name: "Afrikaans",
+	lang:   language.Afrikaans,
+	name:   "Afrikaans",
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 9, 1},
@@ -528,6 +798,7 @@ var afrikaans = distribution{
 		{"W", 3, 3},
 		{"Y", 2, 4},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 102, // wikipedia says 104, but the total is 102
 }
 
@@ -582,6 +853,7 @@ var bulgarian = distribution{
 		{"Ю", 1, 8},
 		{"Я", 2, 5},
 	},
+	vowels:    []string{"А", "Е", "И", "О", "У", "Ъ", "Ю", "Я"},
 	tileCount: 102,
 }
 
@@ -631,6 +903,7 @@ var danish = distribution{
 		{"Æ", 2, 4},
 		{"Ø", 2, 4},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Æ", "Ø", "Å"},
 	tileCount: 100,
 }
 
@@ -683,6 +956,7 @@ var estonian = distribution{
 		{"Š", 1, 10},
 		{"Ž", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Ä", "Õ", "Ö", "Ü"},
 	tileCount: 102,
 }
 
@@ -732,6 +1006,7 @@ var finnish = distribution{
 		{"Ä", 5, 2},
 		{"Ö", 1, 7},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Ä", "Ö"},
 	tileCount: 101,
 }
 
@@ -750,8 +1025,9 @@ var finnish = distribution{
 // | 10 | Ζ Θ Ξ Ψ |         |       |       |    |    |     |       |    |     |
 // +----+---------+---------+-------+-------+----+----+-----+-------+----+-----+
 var greek = distribution{
-	lang: language.Greek,
-	name: "Ελληνικά",
+	lang:   language.Greek,
+	name:   "Ελληνικά",
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"Α", 12, 1},
@@ -779,6 +1055,7 @@ var greek = distribution{
 		{"Ψ", 1, 10},
 		{"Ω", 3, 3},
 	},
+	vowels:    []string{"Α", "Ε", "Η", "Ι", "Ο", "Υ", "Ω"},
 	tileCount: 104,
 }
 
@@ -827,6 +1104,7 @@ var indonesian = distribution{
 		{"Y", 2, 5},
 		{"Z", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 100,
 }
 
@@ -885,6 +1163,7 @@ var latvian = distribution{
 		{"Ū", 1, 6},
 		{"Ž", 1, 8},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Ā", "Ē", "Ī", "Ū"},
 	tileCount: 104,
 }
 
@@ -942,6 +1221,7 @@ var lithuanian = distribution{
 		{"Ų", 1, 6},
 		{"Ž", 1, 6},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Ą", "Ė", "Ę", "Į", "Ū", "Ų"},
 	tileCount: 104,
 }
 
@@ -989,6 +1269,7 @@ var malay = distribution{
 		{"Y", 1, 5},
 		{"Z", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
 	tileCount: 100,
 }
 
@@ -1040,6 +1321,7 @@ var norwegian = distribution{
 		{"Æ", 1, 6},
 		{"Ø", 2, 5},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Æ", "Ø", "Å"},
 	tileCount: 100,
 }
 
@@ -1096,6 +1378,7 @@ var polish = distribution{
 		{"Ź", 1, 9},
 		{"Ż", 1, 5},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Ó", "Ą", "Ę"},
 	tileCount: 100,
 }
 
@@ -1115,8 +1398,9 @@ var polish = distribution{
 // | 8 | X Z |         |         |     |     |     |    |    |     |     |     |
 // +---+-----+---------+---------+-----+-----+-----+----+----+-----+-----+-----+
 var portuguese = distribution{
-	lang: language.Portuguese,
-	name: "Português",
+	lang:   language.Portuguese,
+	name:   "Português",
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 3, 0},
 		{"A", 14, 1},
@@ -1144,6 +1428,7 @@ var portuguese = distribution{
 		{"Z", 1, 8},
 		{"Ç", 2, 3},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
 	tileCount: 120,
 }
 
@@ -1164,8 +1449,10 @@ var portuguese = distribution{
 // | 10 | J X |         |    |    |       |       |    |    |     |     |
 // +----+-----+---------+----+----+-------+-------+----+----+-----+-----+
 var romanian = distribution{
-	lang: language.Romanian,
-	name: "Română",
+	lang:   language.Romanian,
+	name:   "Română",
+	dict:   ro.ToateCuvintele,
+	policy: wordPolicy{fold: true},
 	letters: []letter{
 		{blank, 2, 0},
 		{"A", 10, 1},
@@ -1191,6 +1478,7 @@ var romanian = distribution{
 		{"X", 1, 10},
 		{"Z", 1, 8},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
 	tileCount: 100,
 }
 
@@ -1257,6 +1545,7 @@ var slovak = distribution{
 		{"Ť", 1, 7},
 		{"Ž", 1, 5},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Á", "Ä", "É", "Í", "Ó", "Ô", "Ú", "Ý"},
 	tileCount: 100,
 }
 
@@ -1307,6 +1596,7 @@ var slovenian = distribution{
 		{"Š", 1, 6},
 		{"Ž", 1, 10},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
 	tileCount: 100,
 }
 
@@ -1358,6 +1648,7 @@ var swedish = distribution{
 		{"Å", 2, 4},
 		{"Ö", 2, 4},
 	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y", "Ä", "Å", "Ö"},
 	tileCount: 100,
 }
 
@@ -1418,9 +1709,369 @@ var ukrainian = distribution{
 		{"Ї", 1, 6},
 		{"Ґ", 1, 10},
 	},
+	vowels:    []string{"А", "Е", "И", "О", "У", "Ю", "Я", "Є", "І", "Ї"},
 	tileCount: 104,
 }
 
+// spanish represents the distribution of letters for the
+// standard Spanish edition. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Scrabble_letter_distributions#Spanish
+// +----+-------------+---------+-----+-------+-----+----+-----+
+// |    | ×1          | ×2      | ×4  | ×5    | ×6  | ×9 | ×12 |
+// +----+-------------+---------+-----+-------+-----+----+-----+
+// | 0  |             | [blank] |     |       |     |    |     |
+// | 1  |             |         | L T | N R U | I S | O  | A E |
+// | 2  |             | G       |     | D     |     |    |     |
+// | 3  |             | B M P   | C   |       |     |    |     |
+// | 4  | F V Y       | H       |     |       |     |    |     |
+// | 5  | CH Q        |         |     |       |     |    |     |
+// | 8  | J LL Ñ RR X |         |     |       |     |    |     |
+// | 10 | Z           |         |     |       |     |    |     |
+// +----+-------------+---------+-----+-------+-----+----+-----+
+var spanish = distribution{
+	lang:   language.Spanish,
+	name:   "Español",
+	policy: wordPolicy{fold: true},
+	letters: []letter{
+		{blank, 2, 0},
+		{"A", 12, 1},
+		{"B", 2, 3},
+		{"C", 4, 3},
+		{"CH", 1, 5},
+		{"D", 5, 2},
+		{"E", 12, 1},
+		{"F", 1, 4},
+		{"G", 2, 2},
+		{"H", 2, 4},
+		{"I", 6, 1},
+		{"J", 1, 8},
+		{"L", 4, 1},
+		{"LL", 1, 8},
+		{"M", 2, 3},
+		{"N", 5, 1},
+		{"Ñ", 1, 8},
+		{"O", 9, 1},
+		{"P", 2, 3},
+		{"Q", 1, 5},
+		{"R", 5, 1},
+		{"RR", 1, 8},
+		{"S", 6, 1},
+		{"T", 4, 1},
+		{"U", 5, 1},
+		{"V", 1, 4},
+		{"X", 1, 8},
+		{"Y", 1, 4},
+		{"Z", 1, 10},
+	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
+	tileCount: 100,
+}
+
+// catalan represents the distribution of letters for the
+// standard Catalan edition. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Scrabble_letter_distributions#Catalan
+// +----+---------+---------+-------+-----+-----+----+-------+-----+-----+
+// |    | ×1      | ×2      | ×3    | ×4  | ×5  | ×6 | ×8    | ×12 | ×13 |
+// +----+---------+---------+-------+-----+-----+----+-------+-----+-----+
+// | 0  |         | [blank] |       |     |     |    |       |     |     |
+// | 1  |         |         |       | L U | O T | N  | I R S | A   | E   |
+// | 2  |         |         | C D M |     |     |    |       |     |     |
+// | 3  |         | B G P   |       |     |     |    |       |     |     |
+// | 4  | F V     |         |       |     |     |    |       |     |     |
+// | 5  | H J Q Z |         |       |     |     |    |       |     |     |
+// | 8  | Ç L·L X |         |       |     |     |    |       |     |     |
+// | 10 | NY      |         |       |     |     |    |       |     |     |
+// +----+---------+---------+-------+-----+-----+----+-------+-----+-----+
+var catalan = distribution{
+	lang:   language.Catalan,
+	name:   "Català",
+	policy: wordPolicy{fold: true},
+	letters: []letter{
+		{blank, 2, 0},
+		{"A", 12, 1},
+		{"B", 2, 3},
+		{"C", 3, 2},
+		{"Ç", 1, 8},
+		{"D", 3, 2},
+		{"E", 13, 1},
+		{"F", 1, 4},
+		{"G", 2, 3},
+		{"H", 1, 5},
+		{"I", 8, 1},
+		{"J", 1, 5},
+		{"L", 4, 1},
+		{"L·L", 1, 8},
+		{"M", 3, 2},
+		{"N", 6, 1},
+		{"NY", 1, 10},
+		{"O", 5, 1},
+		{"P", 2, 3},
+		{"Q", 1, 5},
+		{"R", 8, 1},
+		{"S", 8, 1},
+		{"T", 5, 1},
+		{"U", 4, 1},
+		{"V", 1, 4},
+		{"X", 1, 8},
+		{"Z", 1, 5},
+	},
+	vowels:    []string{"A", "E", "I", "O", "U"},
+	tileCount: 100,
+}
+
+// welsh represents the distribution of letters for the
+// standard Welsh edition. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Scrabble_letter_distributions#Welsh
+// +----+---------+---------+---------+------+----+-----+-----+-----+-----+
+// |    | ×1      | ×2      | ×3      | ×4   | ×5 | ×6  | ×7  | ×8  | ×10 |
+// +----+---------+---------+---------+------+----+-----+-----+-----+-----+
+// | 0  |         | [blank] |         |      |    |     |     |     |     |
+// | 1  |         |         |         | DD R | W  | D O | I Y | E N | A   |
+// | 2  |         |         | F G L U |      |    |     |     |     |     |
+// | 3  |         | B M S T |         |      |    |     |     |     |     |
+// | 4  | FF TH   | C H     |         |      |    |     |     |     |     |
+// | 5  | CH LL P |         |         |      |    |     |     |     |     |
+// | 8  | PH      |         |         |      |    |     |     |     |     |
+// | 10 | NG J RH |         |         |      |    |     |     |     |     |
+// +----+---------+---------+---------+------+----+-----+-----+-----+-----+
+var welsh = distribution{
+	lang:   language.MustParse("cy"),
+	name:   "Cymraeg",
+	policy: wordPolicy{fold: true},
+	letters: []letter{
+		{blank, 2, 0},
+		{"A", 10, 1},
+		{"B", 2, 3},
+		{"C", 2, 4},
+		{"CH", 1, 5},
+		{"D", 6, 1},
+		{"DD", 4, 1},
+		{"E", 8, 1},
+		{"F", 3, 2},
+		{"FF", 1, 4},
+		{"G", 3, 2},
+		{"NG", 1, 10},
+		{"H", 2, 4},
+		{"I", 7, 1},
+		{"J", 1, 10},
+		{"L", 3, 2},
+		{"LL", 1, 5},
+		{"M", 2, 3},
+		{"N", 8, 1},
+		{"O", 6, 1},
+		{"P", 1, 5},
+		{"PH", 1, 8},
+		{"R", 4, 1},
+		{"RH", 1, 10},
+		{"S", 2, 3},
+		{"T", 2, 3},
+		{"TH", 1, 4},
+		{"U", 3, 2},
+		{"W", 5, 1},
+		{"Y", 7, 1},
+	},
+	vowels:    []string{"A", "E", "I", "O", "U", "W", "Y"},
+	tileCount: 100,
+}
+
+// hungarian represents the distribution of letters for the
+// standard Hungarian edition. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Scrabble_letter_distributions#Hungarian
+// +----+----------+------------------+---------+---------+----+-------+
+// |    | ×1       | ×2               | ×3      | ×4      | ×5 | ×6    |
+// +----+----------+------------------+---------+---------+----+-------+
+// | 0  |          | [blank]          |         |         |    |       |
+// | 1  |          |                  | I M O S | Á L N R | T  | A E K |
+// | 2  |          |                  | B D G Ó |         |    |       |
+// | 3  |          | H SZ V           | É       |         |    |       |
+// | 4  |          | F GY J Ö P U Ü Z |         |         |    |       |
+// | 5  | C Í NY   |                  |         |         |    |       |
+// | 7  | CS Ő Ú Ű |                  |         |         |    |       |
+// | 8  | LY ZS    |                  |         |         |    |       |
+// | 10 | TY       |                  |         |         |    |       |
+// +----+----------+------------------+---------+---------+----+-------+
+var hungarian = distribution{
+	lang: language.Hungarian,
+	name: "Magyar",
+	letters: []letter{
+		{blank, 2, 0},
+		{"A", 6, 1},
+		{"Á", 4, 1},
+		{"B", 3, 2},
+		{"C", 1, 5},
+		{"CS", 1, 7},
+		{"D", 3, 2},
+		{"E", 6, 1},
+		{"É", 3, 3},
+		{"F", 2, 4},
+		{"G", 3, 2},
+		{"GY", 2, 4},
+		{"H", 2, 3},
+		{"I", 3, 1},
+		{"Í", 1, 5},
+		{"J", 2, 4},
+		{"K", 6, 1},
+		{"L", 4, 1},
+		{"LY", 1, 8},
+		{"M", 3, 1},
+		{"N", 4, 1},
+		{"NY", 1, 5},
+		{"O", 3, 1},
+		{"Ó", 3, 2},
+		{"Ö", 2, 4},
+		{"Ő", 1, 7},
+		{"P", 2, 4},
+		{"R", 4, 1},
+		{"S", 3, 1},
+		{"SZ", 2, 3},
+		{"T", 5, 1},
+		{"TY", 1, 10},
+		{"U", 2, 4},
+		{"Ú", 1, 7},
+		{"Ü", 2, 4},
+		{"Ű", 1, 7},
+		{"V", 2, 3},
+		{"Z", 2, 4},
+		{"ZS", 1, 8},
+	},
+	vowels:    []string{"A", "Á", "E", "É", "I", "Í", "O", "Ó", "Ö", "Ő", "U", "Ú", "Ü", "Ű"},
+	tileCount: 100,
+}
+
+// superScrabble represents the distribution of letters for the
+// English edition of Super Scrabble, played on a larger board
+// with twice as many tiles. It contains 200 tiles.
+// https://en.wikipedia.org/wiki/Super_Scrabble
+// +----+-----+----+---------+----+-----+-----+----+-----+-------+-----+-----+-----+
+// |    | ×2  | ×3 | ×4      | ×5 | ×6  | ×7  | ×8 | ×10 | ×13   | ×15 | ×16 | ×24 |
+// +----+-----+----+---------+----+-----+-----+----+-----+-------+-----+-----+-----+
+// | 0  |     |    | [blank] |    |     |     |    |     |       |     |     |     |
+// | 1  |     |    |         |    |     | L U |    | S   | I N R | O T | A   | E   |
+// | 2  |     |    |         | G  |     |     | D  |     |       |     |     |     |
+// | 3  |     |    | B P     |    | C M |     |    |     |       |     |     |     |
+// | 4  |     | V  | F W Y   | H  |     |     |    |     |       |     |     |     |
+// | 5  | K   |    |         |    |     |     |    |     |       |     |     |     |
+// | 8  | J X |    |         |    |     |     |    |     |       |     |     |     |
+// | 10 | Q Z |    |         |    |     |     |    |     |       |     |     |     |
+// +----+-----+----+---------+----+-----+-----+----+-----+-------+-----+-----+-----+
+var superScrabble = distribution{
+	lang:     language.English,
+	name:     "Super Scrabble",
+	dict:     en.SOWPODS,
+	builtins: englishDicts,
+	policy:   wordPolicy{fold: true},
+	letters: []letter{
+		{blank, 4, 0},
+		{"A", 16, 1},
+		{"B", 4, 3},
+		{"C", 6, 3},
+		{"D", 8, 2},
+		{"E", 24, 1},
+		{"F", 4, 4},
+		{"G", 5, 2},
+		{"H", 5, 4},
+		{"I", 13, 1},
+		{"J", 2, 8},
+		{"K", 2, 5},
+		{"L", 7, 1},
+		{"M", 6, 3},
+		{"N", 13, 1},
+		{"O", 15, 1},
+		{"P", 4, 3},
+		{"Q", 2, 10},
+		{"R", 13, 1},
+		{"S", 10, 1},
+		{"T", 15, 1},
+		{"U", 7, 1},
+		{"V", 3, 4},
+		{"W", 4, 4},
+		{"X", 2, 8},
+		{"Y", 4, 4},
+		{"Z", 2, 10},
+	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
+	tileCount: 200,
+	lengths:   wordLengths{min: 7, max: 10, def: 7},
+}
+
+// junior represents the tiles of the English edition, drawn
+// by the shorter racks of Scrabble Junior. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Scrabble_Junior
+// +----+-----+-----------+----+-------+-------+----+-----+-----+
+// |    | ×1  | ×2        | ×3 | ×4    | ×6    | ×8 | ×9  | ×12 |
+// +----+-----+-----------+----+-------+-------+----+-----+-----+
+// | 0  |     | [blank]   |    |       |       |    |     |     |
+// | 1  |     |           |    | L S U | N R T | O  | A I | E   |
+// | 2  |     |           | G  | D     |       |    |     |     |
+// | 3  |     | B C M P   |    |       |       |    |     |     |
+// | 4  |     | F H V W Y |    |       |       |    |     |     |
+// | 5  | K   |           |    |       |       |    |     |     |
+// | 8  | J X |           |    |       |       |    |     |     |
+// | 10 | Q Z |           |    |       |       |    |     |     |
+// +----+-----+-----------+----+-------+-------+----+-----+-----+
+var junior = distribution{
+	lang:      language.English,
+	name:      "Scrabble Junior",
+	dict:      en.SOWPODS,
+	builtins:  englishDicts,
+	policy:    wordPolicy{fold: true},
+	letters:   english.letters,
+	vowels:    english.vowels,
+	tileCount: 100,
+	lengths:   wordLengths{min: 4, max: 7, def: 5},
+}
+
+// upwords represents the distribution of letters of the
+// English edition of Upwords, also known as Topword. The
+// letters have no points, since the words are scored by
+// the height of their stacks of tiles, and each tile is
+// counted as one point. There is no blank tile, and the Q
+// is only available as a QU tile. It contains 100 tiles.
+// https://en.wikipedia.org/wiki/Upwords
+// +---+------------+-------+-----------+----+---------------+----+-------+----+
+// |   | ×1         | ×2    | ×3        | ×4 | ×5            | ×6 | ×7    | ×8 |
+// +---+------------+-------+-----------+----+---------------+----+-------+----+
+// | 1 | J QU V X Z | K W Y | B F G H P | C  | D L M N R T U | S  | A I O | E  |
+// +---+------------+-------+-----------+----+---------------+----+-------+----+
+var upwords = distribution{
+	lang:     language.English,
+	name:     "Upwords",
+	dict:     en.SOWPODS,
+	builtins: englishDicts,
+	policy:   wordPolicy{fold: true},
+	letters: []letter{
+		{"A", 7, 1},
+		{"B", 3, 1},
+		{"C", 4, 1},
+		{"D", 5, 1},
+		{"E", 8, 1},
+		{"F", 3, 1},
+		{"G", 3, 1},
+		{"H", 3, 1},
+		{"I", 7, 1},
+		{"J", 1, 1},
+		{"K", 2, 1},
+		{"L", 5, 1},
+		{"M", 5, 1},
+		{"N", 5, 1},
+		{"O", 7, 1},
+		{"P", 3, 1},
+		{"QU", 1, 1},
+		{"R", 5, 1},
+		{"S", 6, 1},
+		{"T", 5, 1},
+		{"U", 5, 1},
+		{"V", 1, 1},
+		{"W", 2, 1},
+		{"X", 1, 1},
+		{"Y", 2, 1},
+		{"Z", 1, 1},
+	},
+	vowels:    []string{"A", "E", "I", "O", "U", "Y"},
+	tileCount: 100,
+	lengths:   wordLengths{min: 7, max: 7, def: 7},
+}
+
 // sorted by addition time
 var distributions = map[string]distribution{
 	"french":     french,
@@ -1449,4 +2100,12 @@ var distributions = map[string]distribution{
 	"slovenian":  slovenian,
 	"swedish":    swedish,
 	"ukrainian":  ukrainian,
+	"spanish":    spanish,
+	"catalan":    catalan,
+	"welsh":      welsh,
+	"hungarian":  hungarian,
+
+	"superscrabble": superScrabble,
+	"junior":        junior,
+	"upwords":       upwords,
 }
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"math/rand"
+	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
-	"unicode/utf8"
+	"unicode"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/text/unicode/norm"
 )
 
 func Test_distribution_tiles(t *testing.T) {
@@ -17,7 +22,7 @@ func Test_distribution_tiles(t *testing.T) {
 		if d.tileCount == 0 {
 			t.Errorf("expected distribution %q to have a non-zero tile count", k)
 		}
-		b := newBag(d)
+		b := newBag(d, rand.New(rand.NewSource(1)))
 		if bl := b.length(); bl != d.tileCount {
 			t.Errorf("%s: expected bag to contain %d tiles, got %d", k, d.tileCount, bl)
 		}
@@ -29,13 +34,215 @@ func Test_distribution_letters(t *testing.T) {
 		t.Skip()
 	}
 	// This test ensure that the letters of a distribution are
-	// only represented by a single Unicode code point instead
+	// represented by precomposed Unicode code points instead
 	// of a base character with combining diacritical marks/modifiers.
+	// Digraphs are the only letters made of several code points.
 	for k, d := range distributions {
 		for _, l := range d.letters {
-			if n := utf8.RuneCountInString(l.L); n > 1 {
-				t.Errorf("%s: letter %q has a rune length of %d", k, l.L, n)
+			if !norm.NFC.IsNormalString(l.L) {
+				t.Errorf("%s: letter %q is not NFC-normalized", k, l.L)
 			}
+			for _, r := range l.L {
+				if unicode.Is(unicode.Mn, r) {
+					t.Errorf("%s: letter %q contains a combining mark", k, l.L)
+				}
+			}
+		}
+	}
+}
+
+func Test_distribution_tokenize(t *testing.T) {
+	for _, tt := range []struct {
+		distrib distribution
+		word    string
+		letters []string
+	}{
+		{english, "CHURRO", []string{"C", "H", "U", "R", "R", "O"}},
+		{spanish, "CHURRO", []string{"CH", "U", "RR", "O"}},
+		{spanish, "LLAMA", []string{"LL", "A", "M", "A"}},
+		{spanish, "C H", []string{"C", "H"}},
+		{catalan, "COL·LEGI", []string{"C", "O", "L·L", "E", "G", "I"}},
+		{catalan, "ANY", []string{"A", "NY"}},
+		{welsh, "CYMRAEG", []string{"C", "Y", "M", "R", "A", "E", "G"}},
+		{welsh, "LLANDDWYN", []string{"LL", "A", "N", "DD", "W", "Y", "N"}},
+		{hungarian, "GYÜMÖLCS", []string{"GY", "Ü", "M", "Ö", "L", "CS"}},
+		{hungarian, "SZÉP", []string{"SZ", "É", "P"}},
+	} {
+		if got := tt.distrib.tokenize(tt.word); !reflect.DeepEqual(got, tt.letters) {
+			t.Errorf("%s: got letters %q, want %q", tt.word, got, tt.letters)
+		}
+	}
+}
+
+func Test_distribution_vowels(t *testing.T) {
+	for k, d := range distributions {
+		if len(d.vowels) == 0 {
+			t.Errorf("%s: expected distribution to define its vowels", k)
+		}
+		for _, v := range d.vowels {
+			if !slices.ContainsFunc(d.letters, func(l letter) bool { return l.L == v }) {
+				t.Errorf("%s: vowel %q is not a letter of the distribution", k, v)
+			}
+		}
+	}
+}
+
+func Test_distribution_kind(t *testing.T) {
+	for _, tt := range []struct {
+		lang    string
+		distrib distribution
+		word    string
+		vowels  int
+	}{
+		{"english", english, "SYZYGY", 3},
+		{"french", french, "OISEAU", 5},
+		{"greek", greek, "ΑΛΦΑΒΗΤΟ", 4},
+		{"greek", greek, "ΩΜΕΓΑ", 3},
+		{"bulgarian", bulgarian, "БЪЛГАРИЯ", 4},
+		{"ukrainian", ukrainian, "ЇЖАК", 2},
+		{"ukrainian", ukrainian, "ЄВРОПА", 3},
+		{"icelandic", icelandic, "ÆÐI", 2},
+		{"icelandic", icelandic, "ÞÖRF", 1},
+		{"danish", danish, "ØRKEN", 2},
+		{"danish", danish, "ÆBLE", 2},
+		{"norwegian", norwegian, "BLÅBÆR", 2},
+		{"welsh", welsh, "CWM", 1},
+		{"hungarian", hungarian, "GYŰRŰ", 2},
+	} {
+		t.Run(tt.lang, func(t *testing.T) {
+			r := tilesFromLetters(tt.distrib.tokenize(tt.word), tt.distrib)
+			v, c := r.splitByKind()
+
+			if len(v) != tt.vowels {
+				t.Errorf("%s: got %d vowels, want %d", tt.word, len(v), tt.vowels)
+			}
+			if len(v)+len(c) != len(r) {
+				t.Errorf("%s: expected all tiles to be classified", tt.word)
+			}
+		})
+	}
+}
+
+func Test_distribution_kind_blank(t *testing.T) {
+	d := english
+	if k := d.kind(blank); k != kindConsonant {
+		t.Errorf("expected blank to be a consonant by default, got %s", k)
+	}
+	d.blankVowel = true
+	if k := d.kind(blank); k != kindVowel {
+		t.Errorf("expected blank to be a vowel, got %s", k)
+	}
+	b := newBag(d, rand.New(rand.NewSource(1)))
+	if n := len(b.vowels); n != 46 {
+		t.Errorf("expected bag to contain 46 vowels, got %d", n)
+	}
+}
+
+func Test_distribChoices(t *testing.T) {
+	for _, c := range distribChoices() {
+		d := distributions[c.Name]
+
+		marked := strings.HasSuffix(c.Description, " "+insightsMark)
+		if marked != d.hasDictionary() {
+			t.Errorf("%s: got insights mark %t, want %t", c.Name, marked, d.hasDictionary())
+		}
+	}
+	if french.dict == nil || english.dict == nil {
+		t.Error("expected the french and english dictionaries to always be embedded")
+	}
+}
+
+func Test_distribution_validate(t *testing.T) {
+	for k, d := range distributions {
+		if err := d.validate(); err != nil {
+			t.Errorf("%s: %s", k, err)
+		}
+	}
+	valid := distribution{
+		letters: []letter{
+			{blank, 2, 0},
+			{"A", 3, 1},
+			{"B", 2, 3},
+		},
+		vowels:    []string{"A"},
+		tileCount: 7,
+	}
+	if err := valid.validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		modify func(d *distribution)
+		err    string
+	}{
+		{func(d *distribution) { d.tileCount = 100 }, "tile count is 100, but the letters have 7 tiles"},
+		{func(d *distribution) { d.letters[0].points = 1 }, "blank tiles are worth 1 points instead of zero"},
+		{func(d *distribution) { d.letters[2].L = "A" }, `duplicate letter "A"`},
+		{func(d *distribution) { d.letters[1].frequency, d.tileCount = 0, 4 }, `letter "A" has no tiles`},
+		{func(d *distribution) { d.vowels = []string{"A", "E"} }, `vowel "E" is not a letter`},
+		{func(d *distribution) { d.vowels = []string{"A", "A"} }, `duplicate vowel "A"`},
+		{func(d *distribution) { d.lengths = wordLengths{min: 5, max: 4, def: 5} }, "invalid word lengths: 5 to 4, default 5"},
+		{func(d *distribution) { d.lengths = wordLengths{min: 7, max: 21, def: 7} }, "invalid word lengths: 7 to 21, default 7"},
+		{func(d *distribution) { d.lengths = wordLengths{min: 2, max: 10, def: 8} }, "default word length 8 exceeds the 7 tiles"},
+	} {
+		d := valid
+		d.letters = slices.Clone(valid.letters)
+		tt.modify(&d)
+
+		err := d.validate()
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("got error %v, want %q", err, tt.err)
 		}
 	}
 }
+
+func Test_distribution_wordLength(t *testing.T) {
+	for _, tt := range []struct {
+		key  string
+		n    int
+		want int
+		err  string
+	}{
+		{key: "english", want: 7},
+		{key: "english", n: 8, want: 8},
+		{key: "english", n: 2, want: 2},
+		{key: "english", n: 15, want: 15},
+		{key: "english", n: 1, err: "word length must be between 2 and 15"},
+		{key: "english", n: 16, err: "word length must be between 2 and 15"},
+		{key: "superscrabble", n: 10, want: 10},
+		{key: "junior", want: 5},
+		{key: "junior", n: 3, err: "word length must be between 4 and 7"},
+		{key: "upwords", n: 8, err: "word length must be 7"},
+	} {
+		got, err := distributions[tt.key].wordLength(tt.n)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s/%d: got error %v, want %q", tt.key, tt.n, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s/%d: unexpected error: %s", tt.key, tt.n, err)
+		} else if got != tt.want {
+			t.Errorf("%s/%d: got word length %d, want %d", tt.key, tt.n, got, tt.want)
+		}
+	}
+}
+
+func Test_drawLength(t *testing.T) {
+	if n, err := drawLength(junior, 0, 2, 2, false); err != nil || n != 5 {
+		t.Errorf("got word length %d and error %v, want 5", n, err)
+	}
+	if _, err := drawLength(junior, 4, 3, 2, false); err == nil {
+		t.Error("expected the required vowels and consonants to exceed the word length")
+	}
+	if _, err := drawLength(english, 3, 0, 0, true); err == nil || err.Error() != "the official rules require a word length of at least 4" {
+		t.Errorf("expected the official rules to require four tiles, got error %v", err)
+	}
+	if n, err := drawLength(english, 4, 0, 0, true); err != nil || n != 4 {
+		t.Errorf("got word length %d and error %v, want 4", n, err)
+	}
+	ui := tui{opts: options{wordLength: 8}}
+	if err := ui.initGame("upwords"); err == nil || ui.game != nil {
+		t.Errorf("expected the game not to start, got error %v", err)
+	}
+}
@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+var (
+	verifyDistribs bool
+	distribsJSON   bool
+)
+
+var distributionsCmd = &cobra.Command{
+	Use:   "distributions [key]",
+	Short: "List the letter distributions",
+	Long: "List the letter distributions, including the custom ones, or show\n" +
+		"the frequency and the points of the letters of a distribution.\n\n" +
+		"With --verify, the distributions are checked, and their number of\n" +
+		"tiles, vowels and consonants, and the sum of the points of their\n" +
+		"tiles are reported. The command fails if any of them is invalid.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDistributions,
+}
+
+func runDistributions(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	m, errs := distributions, distribFileErrors
+	if len(args) != 0 {
+		key := args[0]
+		d, ok := distributions[key]
+		err, broken := distribFileErrors[key]
+		switch {
+		case !ok && !broken:
+			return fmt.Errorf("unknown distribution: %s", key)
+		case broken && !verifyDistribs:
+			return err
+		}
+		m, errs = map[string]distribution{}, map[string]error{}
+		if ok {
+			m[key] = d
+		}
+		if broken {
+			errs[key] = err
+		}
+	}
+	w := cmd.OutOrStdout()
+
+	switch {
+	case verifyDistribs:
+		return writeVerification(w, m, errs)
+	case len(args) != 0 && distribsJSON:
+		return writeJSON(w, newDistributionFile(args[0], m[args[0]]))
+	case len(args) != 0:
+		return writeDistribution(w, args[0], m[args[0]])
+	case distribsJSON:
+		var list []distributionSummary
+		for _, k := range distributionKeys(m) {
+			list = append(list, newDistributionSummary(k, m[k]))
+		}
+		return writeJSON(w, list)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "KEY\tNAME\tTILES\tDICTIONARY")
+	for _, k := range distributionKeys(m) {
+		s := newDistributionSummary(k, m[k])
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", s.Key, s.Name, s.Tiles, s.Dictionary)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if n := len(errs); n != 0 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "found %d invalid distribution files, use --verify to report them\n", n)
+	}
+	return nil
+}
+
+// distributionSummary describes a distribution in the list.
+type distributionSummary struct {
+	Key        string `json:"key"`
+	Name       string `json:"name"`
+	Lang       string `json:"lang"`
+	Tiles      int    `json:"tiles"`
+	Dictionary string `json:"dictionary"`
+}
+
+func newDistributionSummary(key string, d distribution) distributionSummary {
+	return distributionSummary{
+		Key:        key,
+		Name:       d.name,
+		Lang:       d.lang.String(),
+		Tiles:      d.stats().tiles,
+		Dictionary: d.dictionaryKind(),
+	}
+}
+
+// dictionaryKind returns whether the dictionary of
+// the distribution is embedded, read from a file, or
+// whether the distribution has none.
+func (d distribution) dictionaryKind() string {
+	switch {
+	case d.dictPath != "":
+		return "file"
+	case d.dict != nil:
+		return "embedded"
+	}
+	return "none"
+}
+
+// newDistributionFile returns the definition of the
+// distribution, as read from a distribution file.
+func newDistributionFile(key string, d distribution) distributionFile {
+	df := distributionFile{
+		Key:         key,
+		Lang:        d.lang.String(),
+		Name:        d.name,
+		Dictionary:  d.dictPath,
+		Vowels:      d.vowels,
+		BlankVowel:  d.blankVowel,
+		FoldAccents: d.policy.fold,
+		TileCount:   d.tileCount,
+	}
+	if d.lengths != (wordLengths{}) {
+		df.WordLength = d.lengths.def
+		df.MinWordLength = d.lengths.min
+		df.MaxWordLength = d.lengths.max
+	}
+	for _, v := range d.letters {
+		df.Letters = append(df.Letters, letterFile{
+			Letter:    v.L,
+			Frequency: v.frequency,
+			Points:    v.points,
+		})
+	}
+	return df
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// writeDistribution writes the description of
+// the distribution, followed by its table.
+func writeDistribution(w io.Writer, key string, d distribution) error {
+	s := d.stats()
+
+	vowels := "by their base Latin character"
+	if d.vowels != nil {
+		vowels = strings.Join(d.vowels, " ")
+	}
+	wl := d.wordLengths()
+
+	lengths := strconv.Itoa(wl.def)
+	if wl.min != wl.max {
+		lengths += fmt.Sprintf(" (%d to %d)", wl.min, wl.max)
+	}
+	dict := d.dictionaryKind()
+	if len(d.builtins) != 0 {
+		var names []string
+		for n := range d.builtins {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		dict += fmt.Sprintf(" (built-in: %s)", strings.Join(names, ", "))
+	}
+	_, err := fmt.Fprintf(w, "%s (%s), %s\n"+
+		"tiles: %d, with %d blanks\n"+
+		"vowels: %s\n"+
+		"word length: %s\n"+
+		"dictionary: %s\n\n%s",
+		d.name, key, d.lang,
+		s.tiles, s.blanks,
+		vowels,
+		lengths,
+		dict,
+		d.table(),
+	)
+	return err
+}
+
+// table returns the letters of the distribution in a
+// table, with a column for each frequency and a row for
+// each number of points, such as the tables of the
+// documentation of the distributions. The letters of
+// each cell are sorted in the order of the language.
+func (d distribution) table() string {
+	var freqs, points []uint
+
+	for _, v := range d.letters {
+		freqs = append(freqs, v.frequency)
+		points = append(points, v.points)
+	}
+	slices.Sort(freqs)
+	slices.Sort(points)
+	freqs = slices.Compact(freqs)
+	points = slices.Compact(points)
+
+	sortCell := cellSorter(d.lang)
+
+	rows := make([][]string, len(points)+1)
+	rows[0] = []string{""}
+	for _, f := range freqs {
+		rows[0] = append(rows[0], "×"+strconv.FormatUint(uint64(f), 10))
+	}
+	for i, p := range points {
+		row := []string{strconv.FormatUint(uint64(p), 10)}
+
+		for _, f := range freqs {
+			var cell []string
+			for _, v := range d.letters {
+				if v.frequency != f || v.points != p {
+					continue
+				}
+				cell = append(cell, v.L)
+			}
+			sortCell(cell)
+			if i := slices.Index(cell, blank); i != -1 {
+				cell[i] = "[blank]"
+			}
+			row = append(row, strings.Join(cell, " "))
+		}
+		rows[i+1] = row
+	}
+	widths := make([]int, len(freqs)+1)
+	for _, row := range rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	var sb strings.Builder
+
+	sep := func() {
+		for _, w := range widths {
+			sb.WriteString("+" + strings.Repeat("-", w+2))
+		}
+		sb.WriteString("+\n")
+	}
+	line := func(row []string) {
+		for i, c := range row {
+			sb.WriteString("| " + c + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)+1))
+		}
+		sb.WriteString("|\n")
+	}
+	sep()
+	line(rows[0])
+	sep()
+	for _, row := range rows[1:] {
+		line(row)
+	}
+	sep()
+
+	return sb.String()
+}
+
+// cellSorter returns the function that sorts the letters of
+// the cells of the tables of the language, in the order of the
+// tables of the documentation of the distributions.
+func cellSorter(tag language.Tag) func([]string) {
+	base, _ := tag.Base()
+
+	order, ok := letterOrders[base]
+	if !ok {
+		return collate.New(collationTag(tag)).SortStrings
+	}
+	return func(cell []string) {
+		slices.SortFunc(cell, func(a, b string) int {
+			return strings.Index(order, a) - strings.Index(order, b)
+		})
+	}
+}
+
+// letterOrders are the orders of the letters of the languages
+// whose tables follow the order of their source, rather than
+// the one of a collation.
+var letterOrders = map[language.Base]string{
+	language.MustParseBase("uk"): "ОАИНВЕІТРКСМДПЛУЗЯБГХЙЧЬЖЇЦШЮЄФЩҐ'",
+}
+
+// collationTag returns the tag of the collation of the language.
+// There is no Norwegian collation, but the alphabet is the same
+// as the Danish one. The Swedish tables also order Ä and Ö as
+// the Danish Æ and Ø, before Å, and the Lithuanian tables order
+// the letters by their base letter, Y included.
+func collationTag(tag language.Tag) language.Tag {
+	switch base, _ := tag.Base(); base {
+	case norwegianBase, swedishBase:
+		return language.Danish
+	case lithuanianBase:
+		return language.Und
+	}
+	return tag
+}
+
+var (
+	norwegianBase, _  = language.Norwegian.Base()
+	swedishBase, _    = language.Swedish.Base()
+	lithuanianBase, _ = language.Lithuanian.Base()
+)
+
+// distributionKeys returns the keys of the distributions, sorted.
+func distributionKeys(m map[string]distribution) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// distributionStats holds the totals of the tiles of a distribution.
+type distributionStats struct {
+	tiles      int
+	blanks     int
+	vowels     int
+	consonants int
+	points     int
+}
+
+func (d distribution) stats() distributionStats {
+	var s distributionStats
+
+	for _, v := range d.letters {
+		n := int(v.frequency)
+		s.tiles += n
+		s.points += n * int(v.points)
+
+		switch {
+		case v.L == blank:
+			s.blanks += n
+		case d.kind(v.L) == kindVowel:
+			s.vowels += n
+		default:
+			s.consonants += n
+		}
+	}
+	return s
+}
+
+// writeVerification writes the totals of the tiles of each
+// distribution, and whether it is valid. The ratio of vowels
+// is computed over the letters, excluding the blank tiles. The
+// errors of the distribution files are reported by key.
+func writeVerification(w io.Writer, m map[string]distribution, errs map[string]error) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	invalid := 0
+
+	keys := distributionKeys(m)
+	for k := range errs {
+		if _, ok := m[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	_, _ = fmt.Fprintln(tw, "KEY\tTILES\tBLANKS\tVOWELS\tCONSONANTS\tPOINTS\tSTATUS")
+	for _, k := range keys {
+		d := m[k]
+		s := d.stats()
+
+		ratio := 0.0
+		if n := s.vowels + s.consonants; n != 0 {
+			ratio = float64(s.vowels) / float64(n) * 100
+		}
+		status := "ok"
+		if err, ok := errs[k]; ok {
+			status = err.Error()
+			invalid++
+		} else if err := d.validate(); err != nil {
+			status = strings.ReplaceAll(err.Error(), "\n", "; ")
+			invalid++
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d (%.0f%%)\t%d\t%d\t%s\n",
+			k,
+			s.tiles,
+			s.blanks,
+			s.vowels,
+			ratio,
+			s.consonants,
+			s.points,
+			status,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if invalid != 0 {
+		return fmt.Errorf("found %d invalid distributions", invalid)
+	}
+	return nil
+}
+
+// lookupDistribution returns the distribution of the key,
+// or the error of the distribution file that defines it.
+func lookupDistribution(key string) (distribution, error) {
+	if err, ok := distribFileErrors[key]; ok {
+		return distribution{}, err
+	}
+	d, ok := distributions[key]
+	if !ok {
+		return distribution{}, fmt.Errorf("unknown distribution: %s", key)
+	}
+	return d, nil
+}
+
+func setupDistributionsFlags() {
+	f := distributionsCmd.Flags()
+
+	f.BoolVar(&verifyDistribs, "verify", false,
+		"check the distributions and report their totals",
+	)
+	f.BoolVar(&distribsJSON, "json", false,
+		"write the distributions in JSON",
+	)
+}
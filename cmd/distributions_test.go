@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func Test_writeVerification(t *testing.T) {
+	invalid := english
+	invalid.tileCount = 98
+
+	var buf bytes.Buffer
+
+	err := writeVerification(&buf, map[string]distribution{
+		"english": english,
+		"invalid": invalid,
+	}, map[string]error{
+		"broken": errors.New("invalid distribution file \"broken.json\": no letters"),
+	})
+	if err == nil || err.Error() != "found 2 invalid distributions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := "KEY       TILES   BLANKS   VOWELS     CONSONANTS   POINTS   STATUS\n" +
+		"broken    0       0        0 (0%)     0            0        invalid distribution file \"broken.json\": no letters\n" +
+		"english   100     2        44 (45%)   54           187      ok\n" +
+		"invalid   100     2        44 (45%)   54           187      tile count is 98, but the letters have 100 tiles\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("got output\n%s\nwant\n%s", got, want)
+	}
+}
+
+// Test_distribution_table compares the table of each
+// distribution with the one of its documentation.
+func Test_distribution_table(t *testing.T) {
+	// The documentation of these distributions
+	// disagrees with the points of some letters.
+	skip := map[string]bool{
+		"czech":   true, // U
+		"finnish": true, // C
+		"german":  true, // P
+		"krafla":  true, // M
+	}
+	f, err := os.Open("distribution.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var (
+		table strings.Builder
+		found int
+		scan  = bufio.NewScanner(f)
+	)
+	for scan.Scan() {
+		line := scan.Text()
+
+		if strings.HasPrefix(line, "// +") || strings.HasPrefix(line, "// |") {
+			table.WriteString(strings.TrimPrefix(line, "// ") + "\n")
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "var "); ok && table.Len() != 0 {
+			name, _, _ = strings.Cut(name, " ")
+			d, ok := distributions[strings.ToLower(name)]
+			if !ok {
+				t.Fatalf("unknown distribution %s", name)
+			}
+			if got, want := d.table(), table.String(); got != want && !skip[name] {
+				t.Errorf("%s: got table\n%s\nwant\n%s", name, got, want)
+			}
+			found++
+		}
+		table.Reset()
+	}
+	if err := scan.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if found == 0 {
+		t.Error("no documented table found")
+	}
+}
+
+func Test_cellSorter(t *testing.T) {
+	for _, tt := range []struct {
+		lang language.Tag
+		cell string
+	}{
+		{language.Lithuanian, "Š Y"},
+		{language.Swedish, "B P Ö Å"},
+		{language.Swedish, "F V Ä"},
+		{language.Norwegian, "Æ Ø Å"},
+		{language.Ukrainian, "Е І Т Р"},
+		{language.Ukrainian, "Ґ '"},
+		{language.French, "E É F"},
+	} {
+		want := strings.Fields(tt.cell)
+		got := slices.Clone(want)
+		slices.Reverse(got)
+
+		if cellSorter(tt.lang)(got); !slices.Equal(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.lang, got, want)
+		}
+	}
+}
+
+func Test_newDistributionFile(t *testing.T) {
+	b, err := json.Marshal(newDistributionFile("french", french))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var df distributionFile
+	if err := json.Unmarshal(b, &df); err != nil {
+		t.Fatal(err)
+	}
+	d, err := df.distribution()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.table(), french.table(); got != want {
+		t.Errorf("got table\n%s\nwant\n%s", got, want)
+	}
+	if d.tileCount != 102 || len(d.vowels) != len(french.vowels) {
+		t.Errorf("got %d tiles and vowels %v", d.tileCount, d.vowels)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var exportFormat string
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export the round-by-round log of a recorded game",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	rec, err := loadRecord(args[0])
+	if err != nil {
+		return err
+	}
+	return writeRounds(os.Stdout, exportFormat, rec, rec.rounds())
+}
+
+// writeRounds writes the rounds of a game using the given
+// format, which is either "text", "csv" or "json".
+func writeRounds(w io.Writer, format string, rec *record, rounds []round) error {
+	switch format {
+	case "text":
+		return writeRoundsText(w, rec, rounds)
+	case "csv":
+		return writeRoundsCSV(w, rounds)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(struct {
+			Seed         int64   `json:"seed"`
+			Distribution string  `json:"distribution"`
+			Rounds       []round `json:"rounds"`
+		}{rec.Seed, rec.Distribution, rounds})
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+func writeRoundsText(w io.Writer, rec *record, rounds []round) error {
+	_, err := fmt.Fprintf(w, "Distribution: %s\nSeed: %d\nWord length: %d\n\n",
+		rec.Distribution,
+		rec.Seed,
+		rec.WordLength,
+	)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "ROUND\tDRAW\tWORD\tMOVE\tREJECTED\tBAG")
+	for _, r := range rounds {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\n",
+			r.Number,
+			r.Draw,
+			r.Word,
+			r.Move,
+			len(r.Rejected),
+			r.BagCount,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if sb := rec.scoreboard(); sb != nil {
+		return writeRanking(w, sb)
+	}
+	return nil
+}
+
+func writeRanking(w io.Writer, sb *scoreboard) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "\nRANK\tPLAYER\tPOINTS\tTOP\n")
+	for i, p := range sb.ranking() {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%d\t%.1f%%\n",
+			i+1,
+			p,
+			sb.totals[p],
+			sb.percent(p),
+		)
+	}
+	_, _ = fmt.Fprintf(tw, "\t\t%d\t\n", sb.top)
+
+	return tw.Flush()
+}
+
+func writeRoundsCSV(w io.Writer, rounds []round) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"round", "draw", "word", "move", "rejected", "bag"}); err != nil {
+		return err
+	}
+	for _, r := range rounds {
+		err := cw.Write([]string{
+			strconv.Itoa(r.Number),
+			r.Draw,
+			r.Word,
+			r.Move,
+			strings.Join(r.Rejected, "|"),
+			strconv.Itoa(r.BagCount),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func setupExportFlags() {
+	f := exportCmd.Flags()
+
+	f.StringVarP(&exportFormat, "format", "f", "text",
+		"output format (text, csv, json)",
+	)
+}
@@ -2,43 +2,94 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"maps"
+	"math/rand"
 
 	"golang.org/x/text/cases"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 )
 
+// officialRounds is the number of rounds during which the
+// official duplicate rules require two vowels and two
+// consonants in each draw, instead of one of each.
+const officialRounds = 15
+
+// minWordLen is the minimum number of letters
+// of the words shown in the insights of a draw.
+const minWordLen = 2
+
 // game represents a Scrabble game.
 type game struct {
-	bag       *tiles
-	draw      *tiles
-	distrib   distribution
-	dict      indexedDict
-	drawCount int
-	playCount int
-	wordLen   int
-	scrabbles []string
+	bag           *tiles
+	draw          *tiles
+	distrib       distribution
+	dict          wordFinder
+	dicts         []gameDict
+	active        int
+	board         *board
+	top           *move
+	lastMove      *move
+	scoreboard    *scoreboard
+	seed          int64
+	drawCount     int
+	playCount     int
+	wordLen       int
+	minVowels     int
+	minConsonants int
+	official      bool
+	predicates    []drawPredicate
+	scrabbles     []string
+	anagrams      []anagram
+	extras        []extraWords
+	// missing holds the words of the insights that
+	// are not part of every dictionary of the game.
+	missing map[string]bool
+}
+
+// gameDict is a dictionary of a game, along with the
+// lexicon that validates the words formed on the board.
+type gameDict struct {
+	name string
+	dict wordFinder
+	lex  wordSet
+	// skipped is the number of words of the dictionary
+	// with letters outside of the distribution.
+	skipped int
+}
+
+// newGame returns a new game for the given distribution.
+// All the draws of the game are determined by the seed of
+// its random number generator.
+func newGame(d distribution, seed int64, wordLen int) *game {
+	rng := rand.New(rand.NewSource(seed))
+
+	return &game{
+		bag:     newBag(d, rng),
+		draw:    &tiles{},
+		distrib: d,
+		seed:    seed,
+		wordLen: wordLen,
+	}
 }
 
 // newBag returns a new full splitTiles filled with the
 // tiles represented by the given distribution.
-func newBag(d distribution) *tiles {
+func newBag(d distribution, rng *rand.Rand) *tiles {
 	bag := &tiles{
 		vowels:     make(rack, 0),
 		consonants: make(rack, 0),
+		rng:        rng,
 	}
 	caser := cases.Upper(d.lang)
 
 	for _, v := range d.letters {
 		// Create a tile that represent the letter
 		// and add it as many times as its frequency.
-		t := tile{
-			letter: letter{
-				L:         caser.String(v.L),
-				frequency: v.frequency,
-				points:    v.points,
-			},
-		}
+		t := d.tile(letter{
+			L:         caser.String(v.L),
+			frequency: v.frequency,
+			points:    v.points,
+		})
 		if t.kind() == kindVowel {
 			bag.vowels.fill(t, v.frequency)
 		} else {
@@ -48,62 +99,219 @@ func newBag(d distribution) *tiles {
 	return bag.shuffle()
 }
 
-func (g *game) drawTiles(minVowels, minConsonants int, predicates ...drawPredicate) {
+// drawTiles completes the draw with tiles from the bag,
+// according to the requirements of the game.
+func (g *game) drawTiles() {
 	g.resetDraw(false)
+
+	// The draw is left untouched once the game is
+	// finished, so that the remaining tiles are kept.
+	if g.endReason() != "" {
+		g.scrabbles, g.anagrams, g.extras, g.top = nil, nil, nil, nil
+		g.missing = nil
+		return
+	}
 	g.drawCount++
+	minVowels, minConsonants := g.requirements()
 
-	for _, p := range predicates {
+	// With the official rules, the remaining tiles of the
+	// previous draw are all put back to the bag if the draw
+	// cannot be completed to meet the requirements. Otherwise,
+	// they are kept, and only the missing tiles are drawn.
+	missing := max(minVowels-len(g.draw.vowels), 0) + max(minConsonants-len(g.draw.consonants), 0)
+	if g.official && g.draw.length()+missing > g.wordLen {
+		g.resetDraw(true)
+	}
+
+	for _, p := range g.predicates {
 		p.Reset(g.draw.tiles())
 	}
-	defer func() {
-		g.scrabbles = g.dict.findWords(g.draw.tiles(), g.distrib)
-	}()
+	defer g.findInsights()
 
 	// Pick first the desired quantity of vowels and
 	// consonants minus any unplayed tiles from the
 	// previous draw, and eventually complete with
 	// random tiles.
 	if minVowels > 0 {
-		v := g.bag.drawByKind(kindVowel, minVowels-len(g.draw.vowels), predicates)
+		v := g.bag.drawByKind(kindVowel, minVowels-len(g.draw.vowels), g.predicates)
 		g.draw.vowels.add(v...)
 	}
 	if minConsonants > 0 {
-		c := g.bag.drawByKind(kindConsonant, minConsonants-len(g.draw.consonants), predicates)
+		c := g.bag.drawByKind(kindConsonant, minConsonants-len(g.draw.consonants), g.predicates)
 		g.draw.consonants.add(c...)
 	}
 	if g.draw.length() == g.wordLen {
 		return
 	}
-	r := g.bag.drawRandom(g.wordLen-g.draw.length(), predicates)
+	r := g.bag.drawRandom(g.wordLen-g.draw.length(), g.predicates)
 	v, c := r.splitByKind()
 
 	g.draw.vowels.add(v...)
 	g.draw.consonants.add(c...)
 }
 
+// findInsights finds the words that can be formed with
+// the tiles of the draw in the active dictionary, and the
+// top move on the board.
+func (g *game) findInsights() {
+	if g.dict != nil {
+		g.scrabbles = g.dict.findWords(g.draw.tiles(), g.distrib)
+		g.anagrams = g.dict.findSubWords(g.draw.tiles(), g.distrib, minWordLen)
+		g.extras = g.dict.findWordsWithExtra(g.draw.tiles(), g.distrib)
+		g.findMissing()
+	}
+	if g.board != nil {
+		g.top = g.board.topMove(g.draw.tiles())
+	}
+}
+
+// findMissing marks the words of the insights that
+// are not valid in the other dictionaries of the game.
+func (g *game) findMissing() {
+	g.missing = nil
+	if len(g.dicts) < 2 {
+		return
+	}
+	g.missing = make(map[string]bool)
+
+	check := func(w string) {
+		if _, ok := g.missing[w]; ok {
+			return
+		}
+		enc := joinLetters(g.distrib.tokenize(w))
+		for i, d := range g.dicts {
+			if i != g.active && !d.dict.contains(enc) {
+				g.missing[w] = true
+				return
+			}
+		}
+		g.missing[w] = false
+	}
+	for _, w := range g.scrabbles {
+		check(w)
+	}
+	for _, a := range g.anagrams {
+		check(a.word)
+	}
+	for _, e := range g.extras {
+		for _, w := range e.words {
+			check(w)
+		}
+	}
+}
+
+// useDict makes the dictionary at the given index the
+// active one, and finds the insights of the current draw
+// again. The draws of the game do not depend on it.
+func (g *game) useDict(i int) {
+	g.active = i
+	g.dict = g.dicts[i].dict
+
+	if g.board != nil {
+		g.board.lex = g.dicts[i].lex
+	}
+	if g.endReason() == "" && !g.draw.isEmpty() {
+		g.findInsights()
+	}
+}
+
+// useDictNamed makes the named dictionary the active one,
+// if it isn't already. Only the board depends on it, so an
+// unknown dictionary is ignored without a board.
+func (g *game) useDictNamed(name string) error {
+	if name == "" || len(g.dicts) == 0 || g.dicts[g.active].name == name {
+		return nil
+	}
+	for i, d := range g.dicts {
+		if d.name == name {
+			g.useDict(i)
+			return nil
+		}
+	}
+	if g.board != nil {
+		return fmt.Errorf("dictionary %q is not loaded", name)
+	}
+	return nil
+}
+
+// requirements returns the minimum number of vowels and
+// consonants of the draw for the current round. With the
+// official rules, the draws contain at least two vowels and
+// two consonants until the 15th round, and at least one of
+// each afterwards.
+func (g *game) requirements() (vowels, consonants int) {
+	if !g.official {
+		return g.minVowels, g.minConsonants
+	}
+	if g.playCount < officialRounds {
+		return 2, 2
+	}
+	return 1, 1
+}
+
+// endReason returns the reason why the game is finished,
+// or an empty string if it is not. Unless the official
+// rules are applied, the game only ends once all the tiles
+// have been played.
+func (g *game) endReason() string {
+	if g.bag.isEmpty() && g.draw.isEmpty() {
+		return "all the tiles have been played"
+	}
+	if !g.official {
+		return ""
+	}
+	// The tiles of the draw that were not played
+	// must be counted along with those of the bag.
+	v, c := g.requirements()
+
+	if len(g.bag.vowels)+len(g.draw.vowels) < v {
+		return "not enough vowels left to complete the draw"
+	}
+	if len(g.bag.consonants)+len(g.draw.consonants) < c {
+		return "not enough consonants left to complete the draw"
+	}
+	return ""
+}
+
 // playWord withdraws the tiles required to play the given
 // word from the slice, or return an error if the word cannot
-// be played, leaving the slice untouched.
+// be played, leaving the slice untouched. With a board, the
+// word is a move made of a position and a word, such as
+// "H8 WORD", which is placed on the board as is.
 func (g *game) playWord(word string, check bool) error {
-	rack := mergeRacks(g.draw.vowels, g.draw.consonants)
-
-	// Normalize word with NFC to combine base
-	// characters and modifiers into single runes,
-	// and uppercase the result.
-	tr := transform.Chain(
-		norm.NFC,
-		cases.Upper(g.distrib.lang),
+	var (
+		m       *move
+		letters []string
 	)
-	nw, _, _ := transform.String(tr, word)
+	if g.board != nil {
+		var err error
+		if m, err = g.board.find(g.draw.tiles(), word); err != nil {
+			return err
+		}
+		letters = m.tiles()
+	} else {
+		// Normalize the word as the words of the dictionary,
+		// so that the accents missing from the distribution
+		// are stripped if the policy folds them. The word is
+		// split into the letters of the distribution, which
+		// might be digraphs represented by a single tile.
+		letters = g.distrib.tokenize(g.distrib.normalizer()(word))
+	}
+	rack := mergeRacks(g.draw.vowels, g.draw.consonants)
 
-	for _, r := range nw {
-		if idx := rack.findTile(string(r)); idx != -1 {
-			_ = rack.pickAt(idx)
+	for _, l := range letters {
+		if idx := rack.findTile(l); idx != -1 {
+			rack.pickAt(idx)
 		} else {
-			return fmt.Errorf("word contains unavailable letter '%c'", r)
+			return fmt.Errorf("word contains unavailable letter '%s'", l)
 		}
 	}
 	if !check {
+		g.lastMove = m
+		if m != nil {
+			g.board.place(*m)
+			log.Printf("move placed: %s\n", m)
+		}
 		for i := range rack {
 			rack[i].inuse = true
 		}
@@ -135,3 +343,59 @@ func (g *game) resetDraw(full bool) {
 		}
 	}
 }
+
+// apply performs the action represented by the event.
+// If the event records the resulting draw, it is compared
+// to the actual draw to detect any divergence.
+func (g *game) apply(e event) error {
+	switch e.Kind {
+	case eventStart, eventReject:
+		g.drawTiles()
+	case eventReset:
+		g.resetDraw(true)
+		g.drawTiles()
+	case eventAccept:
+	case eventPlay:
+		// The move is checked with the lexicon of the
+		// dictionary that was active when it was played.
+		if err := g.useDictNamed(e.Dictionary); err != nil {
+			return err
+		}
+		// The top score must be computed
+		// before the tiles are placed.
+		top := g.topScore(e.Scores)
+
+		// The move of a game played with a board
+		// is replayed from its tiles without one.
+		word := e.Word
+		if g.board == nil && e.Tiles != "" {
+			word = e.Tiles
+		}
+		if err := g.playWord(word, false); err != nil {
+			return err
+		}
+		if g.scoreboard != nil {
+			g.scoreboard.add(top, e.Scores)
+		}
+		g.drawTiles()
+	default:
+		return fmt.Errorf("unknown event: %s", e.Kind)
+	}
+	if e.Draw != "" && e.Draw != g.draw.String() {
+		return fmt.Errorf("draw %q differs from recorded draw %q", g.draw, e.Draw)
+	}
+	if e.Bag != nil && !maps.Equal(e.Bag, g.bagState()) {
+		return fmt.Errorf("bag differs from recorded bag")
+	}
+	return nil
+}
+
+// bagState returns the number of remaining
+// tiles in the bag for each letter.
+func (g *game) bagState() map[string]int {
+	m := make(map[string]int)
+	for _, t := range g.bag.tiles() {
+		m[t.L]++
+	}
+	return m
+}
@@ -1,6 +1,13 @@
 package cmd
 
-import "testing"
+import (
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
 
 func Test_newBag(t *testing.T) {
 	for _, tt := range []struct {
@@ -18,7 +25,7 @@ func Test_newBag(t *testing.T) {
 	} {
 		t.Run(tt.lang, func(t *testing.T) {
 			var (
-				bag   = newBag(tt.distrib)
+				bag   = newBag(tt.distrib, rand.New(rand.NewSource(1)))
 				total = len(bag.vowels) + len(bag.consonants)
 				freqs = make(map[string]uint)
 			)
@@ -40,3 +47,185 @@ func Test_newBag(t *testing.T) {
 		})
 	}
 }
+
+func Test_game_playWord_digraphs(t *testing.T) {
+	g := &game{
+		bag:     newBag(spanish, rand.New(rand.NewSource(1))),
+		distrib: spanish,
+		draw: &tiles{
+			vowels:     tilesFromLetters([]string{"U", "O"}, spanish),
+			consonants: tilesFromLetters([]string{"CH", "RR", "C", "H", "S"}, spanish),
+		},
+	}
+	if err := g.playWord("churro", true); err != nil {
+		t.Errorf("expected word to be playable: %s", err)
+	}
+	// The separated C and H tiles can't be played,
+	// since CH is always matched as a digraph.
+	if err := g.playWord("chuch", true); err == nil {
+		t.Errorf("expected word to contain unavailable letters")
+	}
+	if err := g.playWord("c h s", false); err != nil {
+		t.Fatalf("expected word to be playable: %s", err)
+	}
+	if got, want := g.draw.tiles().String(), "U O CH RR"; got != want {
+		t.Errorf("got remaining tiles %q, want %q", got, want)
+	}
+}
+
+func Test_game_playWord_board(t *testing.T) {
+	lex := lexicon{"ACT", "ACTS", "CAT", "CATS", "SCAT"}
+	slices.Sort(lex)
+
+	g := &game{
+		bag:     newBag(english, rand.New(rand.NewSource(1))),
+		distrib: english,
+		board:   newBoard(english, lex),
+		draw: &tiles{
+			vowels:     tilesFromLetters([]string{"A"}, english),
+			consonants: tilesFromLetters([]string{"C", "T", "S"}, english),
+		},
+	}
+	for _, w := range []string{"ACT", "A1 ACT", "8H TAC", "H8 CATS A"} {
+		if err := g.playWord(w, true); err == nil {
+			t.Errorf("%q: expected move to be refused", w)
+		}
+	}
+	// The move played is placed as is, even though
+	// CATS and SCAT score more than ACT.
+	if err := g.playWord("8h act", false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.lastMove.String(), "8H ACT (10)"; got != want {
+		t.Errorf("got move %q, want %q", got, want)
+	}
+	if got := g.board.squares[8][7].L; got != "C" {
+		t.Errorf("got letter %q below the center, want C", got)
+	}
+	if got, want := g.draw.tiles().String(), "S"; got != want {
+		t.Errorf("got remaining tiles %q, want %q", got, want)
+	}
+	// The letters of the board are part of the word,
+	// but not of the tiles played.
+	if err := g.playWord("H8 acts", true); err == nil {
+		t.Errorf("expected move with the tiles of the board to be refused")
+	}
+	if err := g.playWord("8H acts", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.draw.tiles().String(); got != "" {
+		t.Errorf("got remaining tiles %q, want none", got)
+	}
+}
+
+func Test_game_official(t *testing.T) {
+	g := newGame(french, 42, 7)
+	g.official = true
+
+	g.drawTiles()
+	for g.endReason() == "" {
+		v, c := 2, 2
+		if g.playCount >= officialRounds {
+			v, c = 1, 1
+		}
+		if len(g.draw.vowels) < v || len(g.draw.consonants) < c {
+			t.Fatalf("round %d: draw %s doesn't contain %d vowels and %d consonants",
+				g.playCount+1, g.draw, v, c,
+			)
+		}
+		// Play only the vowels, so that the game
+		// ends before all the tiles are played.
+		var word string
+		for _, t := range g.draw.vowels {
+			word += t.L
+		}
+		if err := g.playWord(word, false); err != nil {
+			t.Fatal(err)
+		}
+		g.drawTiles()
+	}
+	if r := g.endReason(); r != "not enough vowels left to complete the draw" {
+		t.Errorf("unexpected end reason: %s", r)
+	}
+	if g.draw.isEmpty() {
+		t.Errorf("expected remaining tiles to be kept in the draw")
+	}
+}
+
+func Test_game_drawTiles_leftover(t *testing.T) {
+	for _, official := range []bool{false, true} {
+		g := newGame(english, 1, 7)
+		g.minVowels, g.minConsonants = 2, 1
+		g.official = official
+
+		// The six consonants left from the previous draw
+		// leave room for a single vowel out of two.
+		left := g.bag.drawByKind(kindConsonant, 6, nil)
+		for i := range left {
+			left[i].inuse = true
+		}
+		g.draw.consonants.add(left...)
+		g.drawTiles()
+
+		kept := 0
+		for _, t := range g.draw.consonants {
+			if t.inuse {
+				kept++
+			}
+		}
+		if official && kept != 0 {
+			t.Errorf("official: expected the leftover tiles to be put back, %d kept", kept)
+		}
+		if !official && kept != len(left) {
+			t.Errorf("expected the %d leftover tiles to be kept, got %d", len(left), kept)
+		}
+		if len(g.draw.vowels) < 2 {
+			t.Errorf("official %t: got %d vowels, want at least 2", official, len(g.draw.vowels))
+		}
+	}
+}
+
+func Test_game_useDict(t *testing.T) {
+	var dicts []gameDict
+
+	for _, tt := range []struct {
+		name  string
+		words string
+	}{
+		{"twl", "cat\nact\nat\n"},
+		{"collins", "cat\nact\nat\nta\n"},
+	} {
+		dict, err := parseDictionary(io.NopCloser(strings.NewReader(tt.words)), english, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dicts = append(dicts, gameDict{name: tt.name, dict: dict})
+	}
+	g := &game{
+		bag:     newBag(english, rand.New(rand.NewSource(1))),
+		distrib: english,
+		dicts:   dicts,
+		draw: &tiles{
+			vowels:     tilesFromLetters([]string{"A"}, english),
+			consonants: tilesFromLetters([]string{"C", "T"}, english),
+		},
+	}
+	g.useDict(0)
+
+	if !reflect.DeepEqual(g.scrabbles, []string{"CAT", "ACT"}) {
+		t.Errorf("got scrabbles %q", g.scrabbles)
+	}
+	want := map[string]bool{"ACT": false, "CAT": false, "AT": false}
+	if !reflect.DeepEqual(g.missing, want) {
+		t.Errorf("got marked words %v, want %v", g.missing, want)
+	}
+	g.useDict(1)
+
+	if got, want := len(g.anagrams), 4; got != want {
+		t.Errorf("got %d anagrams, want %d", got, want)
+	}
+	want = map[string]bool{"ACT": false, "CAT": false, "AT": false, "TA": true}
+	if !reflect.DeepEqual(g.missing, want) {
+		t.Errorf("got marked words %v, want %v", g.missing, want)
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxResets is the number of consecutive draws that can
+// be reset when no move is found, before the generation
+// of a game is stopped.
+const maxResets = 10
+
+var (
+	generateFormat string
+	generateOutput string
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate the draws of a whole game",
+	Long: "Generate the draws of a whole game without user interaction.\n\n" +
+		"If a dictionary is available for the distribution, the top move is\n" +
+		"played at each round, otherwise all the tiles of the draw are played.\n" +
+		"With several dictionaries, the top move is found with the first one.\n" +
+		"A game generated with the JSON format can be played with the replay\n" +
+		"command.",
+	Args: cobra.NoArgs,
+	RunE: runGenerate,
+}
+
+func runGenerate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	dn := cmd.Flag("distribution").Value.String()
+
+	d, err := lookupDistribution(dn)
+	if err != nil {
+		return err
+	}
+	if err := checkDrawFlags(); err != nil {
+		return err
+	}
+	sources, err := dictionaryFlags(cmd)
+	if err != nil {
+		return err
+	}
+	n, err := drawLength(d, int(wordLength), int(vowels), int(consonants), official)
+	if err != nil {
+		return err
+	}
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return err
+	}
+	d.policy = policy.apply(d.policy)
+
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	rec := &record{
+		Seed:          seed,
+		Distribution:  dn,
+		WordLength:    n,
+		MinVowels:     int(vowels),
+		MinConsonants: int(consonants),
+		Official:      official,
+		Predicates:    predicates.args,
+	}
+	g, err := rec.newGame()
+	if err != nil {
+		return err
+	}
+	g.distrib.policy = d.policy
+
+	// The moves are found with the first dictionary, and
+	// the others are only named in the record, so that the
+	// game can be replayed with the same dictionaries.
+	if len(sources) == 0 {
+		lex, err := d.lexicon()
+		if err != nil {
+			return fmt.Errorf("failed to load lexicon: %s", err)
+		}
+		if lex != nil {
+			g.dicts = []gameDict{{name: "default", lex: lex}}
+		}
+	}
+	for _, src := range sources {
+		lex, err := loadLexiconFile(src.path, d)
+		if err != nil {
+			return fmt.Errorf("failed to load lexicon %q: %s", src.path, err)
+		}
+		g.dicts = append(g.dicts, gameDict{name: src.name, lex: lex})
+	}
+	if len(g.dicts) != 0 {
+		g.board = newBoard(d, nil)
+		g.useDict(0)
+		rec.Board = true
+	}
+	log.SetOutput(io.Discard)
+
+	if err := generateGame(g, rec); err != nil {
+		return err
+	}
+	var w io.Writer = os.Stdout
+
+	if generateOutput != "" {
+		f, err := os.Create(generateOutput)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		w = f
+	}
+	return writeGame(w, generateFormat, rec)
+}
+
+// generateGame plays a whole game and adds its events to
+// the record. The top move is played if the game has a board,
+// otherwise all the tiles of the draw are played, until the
+// game is finished or no move can be found.
+func generateGame(g *game, rec *record) error {
+	do := func(kind eventKind, word string) error {
+		e := event{Kind: kind, Word: word}
+		if err := g.apply(e); err != nil {
+			return err
+		}
+		rec.add(g, e)
+
+		return nil
+	}
+	if err := do(eventStart, ""); err != nil {
+		return err
+	}
+	resets := 0
+
+	for g.endReason() == "" {
+		var word string
+
+		if g.board != nil {
+			if g.top == nil {
+				// Without any move, put back all the tiles
+				// and try again with a new draw, until the
+				// bag is empty.
+				if g.bag.isEmpty() || resets == maxResets {
+					break
+				}
+				if err := do(eventReset, ""); err != nil {
+					return err
+				}
+				resets++
+				continue
+			}
+			word = g.top.notation()
+		} else {
+			// The tiles are separated, so that they
+			// aren't read as digraphs once joined.
+			var letters []string
+			for _, t := range g.draw.tiles() {
+				letters = append(letters, t.L)
+			}
+			word = strings.Join(letters, " ")
+		}
+		resets = 0
+
+		if err := do(eventAccept, ""); err != nil {
+			return err
+		}
+		if err := do(eventPlay, word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGame writes a generated game using the given format.
+// The JSON format is the record of the game, whereas the
+// other formats are the rounds of the game.
+func writeGame(w io.Writer, format string, rec *record) error {
+	if format == "json" {
+		b, err := rec.marshal()
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(b, '\n'))
+
+		return err
+	}
+	return writeRounds(w, format, rec, rec.rounds())
+}
+
+func setupGenerateFlags() {
+	f := generateCmd.Flags()
+	f.SortFlags = false
+
+	f.StringArrayP("dictionary", "d", nil,
+		"custom dictionary file path or built-in name, or name=path (repeatable)",
+	)
+	f.StringP("distribution", "l", "",
+		"letter distribution language",
+	)
+	f.Uint8Var(&vowels, "vowels", 0,
+		"number of required vowel letters",
+	)
+	f.Uint8Var(&consonants, "consonants", 0,
+		"number of required consonant letters",
+	)
+	f.Uint8VarP(&wordLength, "word-length", "w", 0,
+		"the number of tiles to draw (default per distribution)",
+	)
+	f.BoolVar(&official, "official", false,
+		"apply the official duplicate rules",
+	)
+	f.Var(&predicates, "predicates",
+		"list of draw predicates",
+	)
+	f.Int64Var(&seed, "seed", 0,
+		"seed of the random draws (default random)",
+	)
+	f.StringVarP(&generateFormat, "format", "f", "json",
+		"output format (json, csv, text)",
+	)
+	f.StringVarP(&generateOutput, "output", "o", "",
+		"output file path (default stdout)",
+	)
+}
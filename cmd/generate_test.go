@@ -0,0 +1,65 @@
+package cmd
+
+import "testing"
+
+func Test_generateGame(t *testing.T) {
+	for _, withBoard := range []bool{false, true} {
+		rec := &record{
+			Seed:          7,
+			Distribution:  "french",
+			WordLength:    7,
+			MinVowels:     2,
+			MinConsonants: 2,
+		}
+		g, err := rec.newGame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withBoard {
+			g.board = newBoard(french, frenchLexicon(t))
+		}
+		if err := generateGame(g, rec); err != nil {
+			t.Fatal(err)
+		}
+		if err := rec.verify(); err != nil {
+			t.Errorf("expected generated game to be reproducible: %s", err)
+		}
+		rounds := rec.rounds()
+		if len(rounds) == 0 {
+			t.Fatal("expected rounds")
+		}
+		if n := rounds[len(rounds)-1].BagCount; n != 0 {
+			t.Errorf("expected bag to be empty at the end of the game, got %d tiles", n)
+		}
+		for _, r := range rounds {
+			if r.Word == "" {
+				t.Errorf("expected a word to be played in round %d", r.Number)
+			}
+			if withBoard && r.Move == "" {
+				t.Errorf("expected a move to be placed in round %d", r.Number)
+			}
+		}
+	}
+}
+
+func Test_generateGame_digraphs(t *testing.T) {
+	// The tiles of the draws, such as C and H in
+	// Spanish, must not be played as digraphs.
+	for _, dn := range []string{"spanish", "hungarian"} {
+		rec := &record{
+			Seed:         1,
+			Distribution: dn,
+			WordLength:   7,
+		}
+		g, err := rec.newGame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := generateGame(g, rec); err != nil {
+			t.Fatalf("%s: %s", dn, err)
+		}
+		if err := rec.verify(); err != nil {
+			t.Errorf("%s: expected generated game to be reproducible: %s", dn, err)
+		}
+	}
+}
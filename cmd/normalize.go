@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordPolicy defines how the words of the dictionaries
+// of a distribution are normalized and accepted.
+type wordPolicy struct {
+	// fold strips the diacritics of the letters that are
+	// not part of the distribution, such that the French
+	// word "été" is read as ETE.
+	fold bool
+	// reject fails to load a dictionary that contains words
+	// with letters outside of the alphabet of the distribution,
+	// instead of skipping these words.
+	reject bool
+}
+
+// String returns a representation of the policy,
+// which is part of the key of the compiled dictionaries.
+func (p wordPolicy) String() string {
+	var opts []string
+	if p.fold {
+		opts = append(opts, "fold")
+	}
+	if p.reject {
+		opts = append(opts, "reject")
+	} else {
+		opts = append(opts, "skip")
+	}
+	return strings.Join(opts, ",")
+}
+
+// normalizer returns a function that normalizes a word
+// for the distribution: the base characters and modifiers
+// are combined into single runes, the result is uppercased,
+// and the diacritics are stripped according to the policy.
+// The function must not be called concurrently.
+func (d distribution) normalizer() func(string) string {
+	var (
+		tr   = transform.Chain(norm.NFC, cases.Upper(d.lang))
+		fold func(rune) rune
+	)
+	if d.policy.fold {
+		fold = d.folder()
+	}
+	return func(w string) string {
+		w, _, _ = transform.String(tr, w)
+		if fold != nil {
+			w = strings.Map(fold, w)
+		}
+		return w
+	}
+}
+
+// folder returns a function that maps a rune that is not
+// part of the letters of the distribution to its base rune
+// without diacritics, if the latter is part of a letter.
+func (d distribution) folder() func(rune) rune {
+	cache := make(map[rune]rune)
+
+	return func(r rune) rune {
+		if f, ok := cache[r]; ok {
+			return f
+		}
+		f := r
+		if !d.hasRune(r) {
+			if b := stripDiacritics(r); d.hasRune(b) {
+				f = b
+			}
+		}
+		cache[r] = f
+
+		return f
+	}
+}
+
+// stripDiacritics returns the rune without its
+// diacritics, or the rune itself if it has none.
+func stripDiacritics(r rune) rune {
+	tr := transform.Chain(
+		norm.NFD,                           // decompose
+		runes.Remove(runes.In(unicode.Mn)), // remove diacritics
+		norm.NFC,                           // recompose
+	)
+	s, _, _ := transform.String(tr, string(r))
+
+	if b, n := utf8.DecodeRuneInString(s); n == len(s) && b != utf8.RuneError {
+		return b
+	}
+	return r
+}
+
+// checkWord returns an error if one of the letters of a
+// word is not part of the alphabet of the distribution,
+// which must be sorted.
+func checkWord(letters, alphabet []string) error {
+	for _, l := range letters {
+		if _, ok := slices.BinarySearch(alphabet, l); ok {
+			continue
+		}
+		// Some letters of a distribution contain
+		// punctuation, such as the Catalan L·L.
+		if r, _ := utf8.DecodeRuneInString(l); !unicode.IsLetter(r) {
+			return fmt.Errorf("'%s' is not a letter", l)
+		}
+		return fmt.Errorf("'%s' is not a letter of the distribution", l)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_distribution_normalizer(t *testing.T) {
+	for _, tt := range []struct {
+		d    distribution
+		fold bool
+		word string
+		want string
+	}{
+		{french, false, "été", "ÉTÉ"},
+		{french, true, "été", "ETE"},
+		{french, true, "cœur", "CŒUR"},
+		{french, true, "niño", "NINO"},
+		{czech, true, "čeština", "ČEŠTINA"},
+		{czech, true, "öl", "OL"},
+		{german, true, "Ärger", "ÄRGER"},
+	} {
+		d := tt.d
+		d.policy.fold = tt.fold
+
+		if got := d.normalizer()(tt.word); got != tt.want {
+			t.Errorf("%s (fold %t): got %q, want %q", tt.word, tt.fold, got, tt.want)
+		}
+	}
+}
+
+func Test_distribution_policy(t *testing.T) {
+	// The accents are stripped by default in French, whose
+	// tiles are not accented, but not in Czech, in which the
+	// accented letters are distinct tiles.
+	for _, tt := range []struct {
+		d    distribution
+		word string
+		want string
+	}{
+		{french, "été", "ETE"},
+		{spanish, "cañón", "CAÑON"}, // Ñ is a tile, but not Ó
+		{czech, "čaj", "ČAJ"},
+		{polish, "źle", "ŹLE"},
+	} {
+		if got := tt.d.normalizer()(tt.word); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.word, got, tt.want)
+		}
+	}
+	fold := false
+	pf := policyFlags{fold: &fold, reject: true}
+
+	if got, want := pf.apply(french.policy), (wordPolicy{reject: true}); got != want {
+		t.Errorf("got policy %s, want %s", got, want)
+	}
+	if got, want := (policyFlags{}).apply(french.policy), french.policy; got != want {
+		t.Errorf("got policy %s, want %s", got, want)
+	}
+}
+
+func Test_game_playWord_accents(t *testing.T) {
+	g := &game{
+		bag:     newBag(french, rand.New(rand.NewSource(1))),
+		distrib: french,
+		draw: &tiles{
+			vowels:     tilesFromLetters([]string{"E", "E"}, french),
+			consonants: tilesFromLetters([]string{"T"}, french),
+		},
+	}
+	if err := g.playWord("été", true); err != nil {
+		t.Errorf("expected word to be playable: %s", err)
+	}
+	g.distrib.policy.fold = false
+
+	if err := g.playWord("été", true); err == nil {
+		t.Error("expected accented letters to be unavailable")
+	}
+}
+
+func Test_scanWords_policy(t *testing.T) {
+	words := "chat\nété\nniño\nc'est\n\nchien\n"
+
+	for _, tt := range []struct {
+		policy  wordPolicy
+		words   []string
+		skipped int
+		err     bool
+	}{
+		{wordPolicy{}, []string{"CHAT", "CHIEN"}, 3, false},
+		{wordPolicy{fold: true}, []string{"CHAT", "ETE", "NINO", "CHIEN"}, 1, false},
+		{wordPolicy{reject: true}, nil, 0, true},
+		{wordPolicy{fold: true, reject: true}, nil, 0, true},
+	} {
+		d := french
+		d.policy = tt.policy
+
+		var got []string
+		skipped, err := scanWords(strings.NewReader(words), d, func(w string, _ []string) {
+			got = append(got, w)
+		})
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.policy)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.words) {
+			t.Errorf("%s: got words %v, want %v", tt.policy, got, tt.words)
+		}
+		if skipped != tt.skipped {
+			t.Errorf("%s: got %d skipped words, want %d", tt.policy, skipped, tt.skipped)
+		}
+	}
+}
+
+func Test_parseDictionary_policy(t *testing.T) {
+	d := french
+	d.policy.fold = true
+
+	dict, err := parseDictionary(io.NopCloser(strings.NewReader("été\n")), d, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dict.findWords(tilesFromWord("TEE", d), d); !reflect.DeepEqual(got, []string{"ETE"}) {
+		t.Errorf("got words %v, want [ETE]", got)
+	}
+}
@@ -15,6 +15,7 @@ type drawPredicate interface {
 
 type predicateList struct {
 	value   []drawPredicate
+	args    []string
 	changed bool
 }
 
@@ -24,6 +25,7 @@ func (pl predicateList) Type() string   { return "key=[val],..." }
 func (pl *predicateList) Set(val string) error {
 	ss := strings.Split(val, ",")
 	ps := make([]drawPredicate, 0, len(ss))
+	as := make([]string, 0, len(ss))
 
 	for _, pair := range ss {
 		p := strings.TrimSpace(pair)
@@ -49,11 +51,14 @@ func (pl *predicateList) Set(val string) error {
 		default:
 			return fmt.Errorf("unknown predicate: %s", name)
 		}
+		as = append(as, p)
 	}
 	if !pl.changed {
 		pl.value = ps
+		pl.args = as
 	} else {
 		pl.value = append(pl.value, ps...)
+		pl.args = append(pl.args, as...)
 	}
 	pl.changed = true
 
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type eventKind string
+
+const (
+	eventStart  eventKind = "start"
+	eventReject eventKind = "reject"
+	eventReset  eventKind = "reset"
+	eventAccept eventKind = "accept"
+	eventPlay   eventKind = "play"
+)
+
+// event represents an action performed during a game,
+// along with the draw and the state of the bag that
+// resulted from it.
+type event struct {
+	Kind   eventKind      `json:"kind"`
+	Word   string         `json:"word,omitempty"`
+	Draw   string         `json:"draw,omitempty"`
+	Move   string         `json:"move,omitempty"`
+	Tiles  string         `json:"tiles,omitempty"`
+	Scores []score        `json:"scores,omitempty"`
+	Top    int            `json:"top,omitempty"`
+	Bag    map[string]int `json:"bag,omitempty"`
+	Time   *time.Time     `json:"time,omitempty"`
+	// Dictionary is the name of the active dictionary,
+	// among several, when the word was played.
+	Dictionary string `json:"dictionary,omitempty"`
+}
+
+// round represents a round of a game, made of the
+// accepted draw, the word played, and the draws that
+// were rejected beforehand.
+type round struct {
+	Number   int      `json:"number"`
+	Draw     string   `json:"draw"`
+	Word     string   `json:"word,omitempty"`
+	Move     string   `json:"move,omitempty"`
+	Scores   []score  `json:"scores,omitempty"`
+	Top      int      `json:"top,omitempty"`
+	Rejected []string `json:"rejected,omitempty"`
+	BagCount int      `json:"bag_count"`
+}
+
+// record holds the settings and the sequence of events
+// of a game. Since the draws are determined by the seed
+// of the game, it is enough to reproduce a game draw
+// for draw.
+type record struct {
+	Seed          int64    `json:"seed"`
+	Distribution  string   `json:"distribution"`
+	WordLength    int      `json:"word_length"`
+	MinVowels     int      `json:"min_vowels,omitempty"`
+	MinConsonants int      `json:"min_consonants,omitempty"`
+	Official      bool     `json:"official,omitempty"`
+	Board         bool     `json:"board,omitempty"`
+	Predicates    []string `json:"predicates,omitempty"`
+	Players       []string `json:"players,omitempty"`
+	Events        []event  `json:"events"`
+}
+
+func loadRecord(path string) (*record, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("invalid record: %s", err)
+	}
+	if len(r.Events) == 0 || r.Events[0].Kind != eventStart {
+		return nil, fmt.Errorf("invalid record: missing start event")
+	}
+	return &r, nil
+}
+
+func (r *record) save(path string) error {
+	b, err := r.marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+func (r *record) marshal() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// add appends the event to the record, along with
+// the resulting state of the game.
+func (r *record) add(g *game, e event) {
+	e.Draw = g.draw.String()
+	e.Bag = g.bagState()
+	now := time.Now()
+	e.Time = &now
+
+	if e.Kind == eventPlay && g.lastMove != nil {
+		e.Move = g.lastMove.String()
+		e.Tiles = strings.Join(g.lastMove.tiles(), " ")
+	}
+	if e.Kind == eventPlay && len(g.dicts) > 1 {
+		e.Dictionary = g.dicts[g.active].name
+	}
+	if e.Kind == eventPlay && g.scoreboard != nil {
+		e.Top = g.scoreboard.lastTop()
+	}
+	r.Events = append(r.Events, e)
+}
+
+// newGame returns a new game configured with the
+// settings of the record, without any draw.
+func (r *record) newGame() (*game, error) {
+	d, err := lookupDistribution(r.Distribution)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := r.predicates()
+	if err != nil {
+		return nil, err
+	}
+	g := newGame(d, r.Seed, r.WordLength)
+	g.minVowels = r.MinVowels
+	g.minConsonants = r.MinConsonants
+	g.official = r.Official
+	g.predicates = ps
+
+	if len(r.Players) != 0 {
+		g.scoreboard = newScoreboard(r.Players)
+	}
+	return g, nil
+}
+
+// options returns a copy of the given options
+// overridden with the draw settings of the record.
+// The board is tracked if the game was played on
+// it, since the words played are then moves.
+func (r *record) options(opts options) (options, error) {
+	ps, err := r.predicates()
+	if err != nil {
+		return opts, err
+	}
+	opts.seed = r.Seed
+	opts.wordLength = r.WordLength
+	opts.minVowels = r.MinVowels
+	opts.minConsonants = r.MinConsonants
+	opts.official = r.Official
+	opts.board = r.Board
+	opts.predicates = ps
+	opts.predicateArgs = r.Predicates
+	opts.players = r.Players
+
+	return opts, nil
+}
+
+// predicates returns new instances of
+// the draw predicates of the record.
+func (r *record) predicates() ([]drawPredicate, error) {
+	var pl predicateList
+	for _, p := range r.Predicates {
+		if err := pl.Set(p); err != nil {
+			return nil, err
+		}
+	}
+	return pl.value, nil
+}
+
+// verify replays all the events of the record on
+// a new game, and ensure that the draws are identical.
+func (r *record) verify() error {
+	g, err := r.newGame()
+	if err != nil {
+		return err
+	}
+	for i, e := range r.Events {
+		if err := g.apply(e); err != nil {
+			return fmt.Errorf("event #%d (%s): %s", i+1, e.Kind, err)
+		}
+	}
+	return nil
+}
+
+// rounds returns the rounds of the recorded game.
+// The last round is incomplete if no word has been
+// played after the last accepted draw.
+func (r *record) rounds() []round {
+	var (
+		rounds []round
+		prev   event
+		curr   = round{Number: 1}
+	)
+	for _, e := range r.Events {
+		switch e.Kind {
+		case eventReject, eventReset:
+			curr.Rejected = append(curr.Rejected, prev.Draw)
+		case eventAccept:
+			curr.Draw = e.Draw
+			curr.BagCount = bagCount(e.Bag)
+		case eventPlay:
+			curr.Word = e.Word
+			curr.Move = e.Move
+			curr.Scores = e.Scores
+			curr.Top = e.Top
+			rounds = append(rounds, curr)
+			curr = round{Number: curr.Number + 1}
+		}
+		prev = e
+	}
+	if curr.Draw != "" {
+		rounds = append(rounds, curr)
+	}
+	return rounds
+}
+
+// scoreboard returns the scoreboard of the recorded
+// game, or nil if the scores of the players were not
+// recorded.
+func (r *record) scoreboard() *scoreboard {
+	if len(r.Players) == 0 {
+		return nil
+	}
+	s := newScoreboard(r.Players)
+	for _, e := range r.Events {
+		if e.Kind == eventPlay {
+			s.add(e.Top, e.Scores)
+		}
+	}
+	return s
+}
+
+func bagCount(bag map[string]int) int {
+	n := 0
+	for _, c := range bag {
+		n += c
+	}
+	return n
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func Test_newGame_seed(t *testing.T) {
+	draws := func(seed int64) []string {
+		g := newGame(french, seed, 7)
+		g.minVowels, g.minConsonants = 2, 2
+
+		var ds []string
+		g.drawTiles()
+		for i := 0; i < 10; i++ {
+			ds = append(ds, g.draw.String())
+			if err := g.playWord(firstLetters(g.draw, 4), false); err != nil {
+				t.Fatal(err)
+			}
+			g.drawTiles()
+		}
+		return ds
+	}
+	d1, d2, d3 := draws(42), draws(42), draws(43)
+
+	if strings.Join(d1, ",") != strings.Join(d2, ",") {
+		t.Errorf("expected draws with the same seed to be identical")
+	}
+	if strings.Join(d1, ",") == strings.Join(d3, ",") {
+		t.Errorf("expected draws with different seeds to differ")
+	}
+}
+
+func Test_record_verify(t *testing.T) {
+	rec := &record{
+		Seed:          1337,
+		Distribution:  "english",
+		WordLength:    7,
+		MinVowels:     1,
+		MinConsonants: 1,
+		Predicates:    []string{"dup-vowels=2"},
+	}
+	g, err := rec.newGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	do := func(kind eventKind, word string) {
+		e := event{Kind: kind, Word: word}
+		if err := g.apply(e); err != nil {
+			t.Fatal(err)
+		}
+		e.Draw = g.draw.String()
+		e.Bag = g.bagState()
+		rec.Events = append(rec.Events, e)
+	}
+	do(eventStart, "")
+	do(eventReject, "")
+	do(eventReset, "")
+	do(eventAccept, "")
+	do(eventPlay, firstLetters(g.draw, 5))
+	do(eventReject, "")
+	do(eventAccept, "")
+	do(eventPlay, firstLetters(g.draw, 3))
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := rec.save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadRecord(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The events were not timed.
+	if b, _ := os.ReadFile(path); strings.Contains(string(b), `"time"`) {
+		t.Errorf("expected events without time to omit it")
+	}
+	if err := loaded.verify(); err != nil {
+		t.Errorf("expected record to be reproducible: %s", err)
+	}
+	// Altering a draw or the bag must be detected.
+	loaded.Events[4].Bag["Z"]++
+	if err := loaded.verify(); err == nil {
+		t.Errorf("expected altered bag to diverge")
+	}
+	loaded.Events[4].Bag["Z"]--
+	loaded.Events[2].Draw = "A B C D E F G"
+	if err := loaded.verify(); err == nil {
+		t.Errorf("expected altered draw to diverge")
+	}
+}
+
+func Test_record_dictionary(t *testing.T) {
+	rec := &record{
+		Seed:         5,
+		Distribution: "english",
+		WordLength:   7,
+	}
+	g, err := rec.newGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.drawTiles()
+	word := firstLetters(g.draw, 2)
+
+	// The word is only valid in the second dictionary.
+	newGame := func() *game {
+		g, err := rec.newGame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.dicts = []gameDict{
+			{name: "twl", lex: lexicon{}},
+			{name: "collins", lex: lexicon{word}},
+		}
+		g.board = newBoard(english, nil)
+		g.useDict(0)
+
+		return g
+	}
+	g = newGame()
+	for _, e := range []event{
+		{Kind: eventStart},
+		{Kind: eventAccept},
+		{Kind: eventPlay, Word: "H8 " + word},
+	} {
+		if e.Kind == eventPlay {
+			g.useDict(1)
+		}
+		if err := g.apply(e); err != nil {
+			t.Fatal(err)
+		}
+		rec.add(g, e)
+	}
+	if got := rec.Events[2].Dictionary; got != "collins" {
+		t.Errorf("got dictionary %q, want collins", got)
+	}
+	replay := func() error {
+		g := newGame()
+		for _, e := range rec.Events {
+			if err := g.apply(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := replay(); err != nil {
+		t.Errorf("expected game to be replayed with the same dictionary: %s", err)
+	}
+	rec.Events[2].Dictionary = ""
+	if err := replay(); err == nil {
+		t.Errorf("expected move to be refused by the first dictionary")
+	}
+}
+
+func Test_record_rounds(t *testing.T) {
+	rec := &record{
+		Events: []event{
+			{Kind: eventStart, Draw: "A B C D E F G"},
+			{Kind: eventReject, Draw: "H I J K L M N"},
+			{Kind: eventReset, Draw: "O P Q R S T U"},
+			{Kind: eventAccept, Draw: "O P Q R S T U", Bag: map[string]int{"A": 2, "B": 3}},
+			{Kind: eventPlay, Word: "POT", Draw: "Q R S U V W X"},
+			{Kind: eventAccept, Draw: "Q R S U V W X", Bag: map[string]int{"A": 1}},
+		},
+	}
+	rounds := rec.rounds()
+
+	want := []round{
+		{
+			Number:   1,
+			Draw:     "O P Q R S T U",
+			Word:     "POT",
+			Rejected: []string{"A B C D E F G", "H I J K L M N"},
+			BagCount: 5,
+		},
+		{
+			Number:   2,
+			Draw:     "Q R S U V W X",
+			BagCount: 1,
+		},
+	}
+	if !reflect.DeepEqual(rounds, want) {
+		t.Errorf("got rounds %+v, want %+v", rounds, want)
+	}
+	var sb strings.Builder
+	if err := writeRounds(&sb, "csv", rec, rounds); err != nil {
+		t.Fatal(err)
+	}
+	const csv = "round,draw,word,move,rejected,bag\n" +
+		"1,O P Q R S T U,POT,,A B C D E F G|H I J K L M N,5\n" +
+		"2,Q R S U V W X,,,,1\n"
+
+	if got := sb.String(); got != csv {
+		t.Errorf("got CSV output %q, want %q", got, csv)
+	}
+}
+
+func firstLetters(s *tiles, n int) string {
+	var sb strings.Builder
+	for i, t := range s.tiles() {
+		if i == n {
+			break
+		}
+		sb.WriteString(t.L)
+	}
+	return sb.String()
+}
+
+func Test_tui_replay_diverged(t *testing.T) {
+	rec := &record{
+		Seed:         9,
+		Distribution: "spanish",
+		WordLength:   7,
+	}
+	g, err := rec.newGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []event{
+		{Kind: eventStart},
+		{Kind: eventAccept},
+		{Kind: eventPlay},
+	} {
+		if e.Kind == eventPlay {
+			e.Word = g.draw.tiles()[0].L
+		}
+		if err := g.apply(e); err != nil {
+			t.Fatal(err)
+		}
+		rec.add(g, e)
+	}
+	rec.Events[2].Draw = "A B C D E F G"
+
+	ui, err := newTUI("spanish", 80, 24, options{replay: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.Init()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	for i := 0; i < 2; i++ {
+		m, _ := ui.Update(enter)
+		if m == nil {
+			t.Fatal("expected the model to be returned")
+		}
+		_ = m.View()
+	}
+	if ui.err == nil {
+		t.Errorf("expected the divergence to be reported")
+	}
+}
+
+func Test_tui_replay_board(t *testing.T) {
+	for _, board := range []bool{false, true} {
+		rec := &record{
+			Seed:         5,
+			Distribution: "english",
+			WordLength:   7,
+			Board:        board,
+		}
+		g, err := rec.newGame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.drawTiles()
+		word := firstLetters(g.draw, 2)
+
+		path := filepath.Join(t.TempDir(), "words.txt")
+		if err := os.WriteFile(path, []byte(word+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		g, _ = rec.newGame()
+		if board {
+			g.board = newBoard(english, lexicon{word})
+			word = "H8 " + word
+		}
+		for _, e := range []event{
+			{Kind: eventStart},
+			{Kind: eventAccept},
+			{Kind: eventPlay, Word: word},
+		} {
+			if err := g.apply(e); err != nil {
+				t.Fatal(err)
+			}
+			rec.add(g, e)
+		}
+		// The replay follows the mode of the record,
+		// whatever the board flag.
+		opts, err := rec.options(options{
+			dicts:  []dictSource{{name: "words", path: path}},
+			board:  !board,
+			replay: rec,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ui, err := newTUI("english", 80, 24, opts)
+		if err != nil {
+			t.Fatalf("board %t: %s", board, err)
+		}
+		ui.Init()
+
+		for i := 0; i < 2; i++ {
+			ui.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		if ui.err != nil {
+			t.Errorf("board %t: unexpected error: %s", board, ui.err)
+		}
+		if got := ui.game.board != nil; got != board {
+			t.Errorf("got board %t, want %t", got, board)
+		}
+		if board && ui.game.board.isEmpty() {
+			t.Errorf("expected the move to be placed on the board")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay a recorded game draw for draw",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReplay,
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	rec, err := loadRecord(args[0])
+	if err != nil {
+		return err
+	}
+	// Ensure that the game can be reproduced
+	// before starting to replay it.
+	if err := rec.verify(); err != nil {
+		return fmt.Errorf("cannot replay game: %s", err)
+	}
+	dicts, err := dictionaryFlags(cmd)
+	if err != nil {
+		return err
+	}
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return err
+	}
+	opts, err := rec.options(options{
+		dicts:         dicts,
+		defsPath:      defsPath,
+		policy:        policy,
+		showPoints:    showPoints,
+		timerDuration: timerDuration,
+		replay:        rec,
+	})
+	if err != nil {
+		return err
+	}
+	return runTUI(rec.Distribution, opts)
+}
+
+func setupReplayFlags() {
+	f := replayCmd.Flags()
+	f.SortFlags = false
+
+	f.StringArrayP("dictionary", "d", nil,
+		"custom dictionary file path or built-in name, or name=path (repeatable)",
+	)
+	f.StringVar(&defsPath, "definitions", "",
+		"file of the definitions of the words",
+	)
+	f.BoolVarP(&showPoints, "show-points", "p", false,
+		"show letter points in tiles",
+	)
+	f.DurationVarP(&timerDuration, "timer", "t", 0,
+		"enable play timer (default 5m)",
+	)
+	f.StringVar(&debugLogFile, "debug", "",
+		"enable debug mode",
+	)
+	f.Lookup("debug").NoOptDefVal = "debug.log"
+	f.Lookup("timer").NoOptDefVal = "5m"
+}
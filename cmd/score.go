@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// score represents the play of a player for a round,
+// which is either described by its move on the board,
+// or only by its number of points.
+type score struct {
+	Player string `json:"player"`
+	Play   string `json:"play,omitempty"`
+	Points int    `json:"points"`
+}
+
+// scoreboard keeps the total points of the players
+// of a game, compared to the sum of the top scores of
+// each round, as in duplicate games.
+type scoreboard struct {
+	players []string
+	totals  map[string]int
+	tops    []int
+	top     int
+}
+
+func newScoreboard(players []string) *scoreboard {
+	return &scoreboard{
+		players: players,
+		totals:  make(map[string]int, len(players)),
+	}
+}
+
+// add adds the scores of the players for a round
+// for which the top score is given.
+func (s *scoreboard) add(top int, scores []score) {
+	s.tops = append(s.tops, top)
+	s.top += top
+
+	for _, sc := range scores {
+		s.totals[sc.Player] += sc.Points
+	}
+}
+
+// percent returns the percentage of the
+// sum of the top scores made by the player.
+func (s *scoreboard) percent(player string) float64 {
+	if s.top == 0 {
+		return 0
+	}
+	return 100 * float64(s.totals[player]) / float64(s.top)
+}
+
+// ranking returns the players sorted by their total
+// points. Players with the same total keep their order.
+func (s *scoreboard) ranking() []string {
+	r := make([]string, len(s.players))
+	copy(r, s.players)
+
+	sort.SliceStable(r, func(i, j int) bool {
+		return s.totals[r[i]] > s.totals[r[j]]
+	})
+	return r
+}
+
+func (s *scoreboard) view() string {
+	var (
+		sb    strings.Builder
+		width int
+	)
+	for _, p := range s.players {
+		width = max(width, len([]rune(p)))
+	}
+	sb.WriteString(boldText.Render("Scoreboard"))
+	sb.WriteString(faintText.Render(fmt.Sprintf(" (top %d)", s.top)))
+	sb.WriteString(strings.Repeat("\n", 2))
+
+	for i, p := range s.ranking() {
+		sb.WriteString(fmt.Sprintf("%d. %-*s %5d %6.1f%%\n",
+			i+1,
+			width, p,
+			s.totals[p],
+			s.percent(p),
+		))
+	}
+	return sb.String()
+}
+
+// lastTop returns the top score of the last round.
+func (s *scoreboard) lastTop() int {
+	if len(s.tops) == 0 {
+		return 0
+	}
+	return s.tops[len(s.tops)-1]
+}
+
+// scorePlay returns the score of the play entered
+// for a player, which is either a number of points or
+// a move on the board, such as "H8 WORD". A move must
+// be playable with the tiles of the current draw.
+func (g *game) scorePlay(player, play string) (score, error) {
+	play = strings.TrimSpace(play)
+
+	if n, err := strconv.Atoi(play); err == nil {
+		if n < 0 {
+			return score{}, fmt.Errorf("score cannot be negative")
+		}
+		return score{Player: player, Points: n}, nil
+	}
+	if g.board == nil {
+		return score{}, fmt.Errorf("invalid score: %s", play)
+	}
+	m, err := g.board.find(g.draw.tiles(), play)
+	if err != nil {
+		return score{}, err
+	}
+	return score{
+		Player: player,
+		Play:   m.notation(),
+		Points: m.score,
+	}, nil
+}
+
+// topScore returns the top score of the current
+// round. Without a board, the top score is the best
+// score made by the players.
+func (g *game) topScore(scores []score) int {
+	top := 0
+	if g.top != nil {
+		top = g.top.score
+	}
+	for _, sc := range scores {
+		top = max(top, sc.Points)
+	}
+	return top
+}
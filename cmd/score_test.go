@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func Test_scoreboard(t *testing.T) {
+	sb := newScoreboard([]string{"alice", "bob", "carol"})
+
+	sb.add(80, []score{
+		{Player: "alice", Points: 40},
+		{Player: "bob", Points: 80},
+		{Player: "carol", Points: 40},
+	})
+	sb.add(20, []score{
+		{Player: "alice", Points: 20},
+		{Player: "bob", Points: 0},
+		{Player: "carol", Points: 20},
+	})
+	if sb.top != 100 {
+		t.Errorf("expected top of 100, got %d", sb.top)
+	}
+	if p := sb.percent("alice"); math.Abs(p-60) > 1e-9 {
+		t.Errorf("expected alice to make 60%% of the top, got %f", p)
+	}
+	// Players with the same total keep their order.
+	want := []string{"bob", "alice", "carol"}
+	if r := sb.ranking(); !reflect.DeepEqual(r, want) {
+		t.Errorf("got ranking %v, want %v", r, want)
+	}
+}
+
+func Test_game_scorePlay(t *testing.T) {
+	lex := lexicon{"ACT", "AT", "CAT", "CATS", "SCAT", "TA"}
+	slices.Sort(lex)
+
+	g := newGame(english, 1, 7)
+	g.board = newBoard(english, lex)
+	g.draw.consonants = tilesFromWord("CTS", english)
+	g.draw.vowels = tilesFromWord("A", english)
+
+	for _, tt := range []struct {
+		play   string
+		points int
+		move   string
+		err    bool
+	}{
+		{"42", 42, "", false},
+		{"-1", 0, "", true},
+		{"h8 cats", 12, "H8 CATS", false},
+		{"8H SCAT", 12, "8H SCAT", false},
+		{"H8 TACS", 0, "", true},
+		{"A1 CAT", 0, "", true},
+		{"CATS", 0, "", true},
+	} {
+		sc, err := g.scorePlay("alice", tt.play)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: unexpected error: %v", tt.play, err)
+			continue
+		}
+		if sc.Points != tt.points || sc.Play != tt.move {
+			t.Errorf("%q: got score %+v, want %d points and move %q", tt.play, sc, tt.points, tt.move)
+		}
+	}
+	// Without a board, only points can be entered.
+	g.board = nil
+	if _, err := g.scorePlay("alice", "H8 CATS"); err == nil {
+		t.Errorf("expected move to be rejected without a board")
+	}
+}
+
+func Test_record_scoreboard(t *testing.T) {
+	rec := &record{
+		Seed:         3,
+		Distribution: "english",
+		WordLength:   7,
+		Players:      []string{"alice", "bob"},
+	}
+	g, err := rec.newGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	do := func(e event) {
+		if err := g.apply(e); err != nil {
+			t.Fatal(err)
+		}
+		rec.add(g, e)
+	}
+	do(event{Kind: eventStart})
+	do(event{Kind: eventAccept})
+	do(event{Kind: eventPlay, Word: firstLetters(g.draw, 3), Scores: []score{
+		{Player: "alice", Points: 12},
+		{Player: "bob", Points: 30},
+	}})
+	if err := rec.verify(); err != nil {
+		t.Fatal(err)
+	}
+	sb := rec.scoreboard()
+	if sb == nil {
+		t.Fatal("expected a scoreboard")
+	}
+	// Without a board, the top is the best score.
+	if sb.top != 30 || sb.totals["alice"] != 12 {
+		t.Errorf("unexpected scoreboard: top %d, totals %v", sb.top, sb.totals)
+	}
+}
@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// maxPatternLen is the maximum number of elements of a
+// pattern, whose matching states are stored in a bitset.
+const maxPatternLen = 63
+
+var (
+	searchMinLen uint8
+	searchMaxLen uint8
+	anagramExact bool
+)
+
+var anagramCmd = &cobra.Command{
+	Use:   "anagram <letters>",
+	Short: "Find the words that can be formed with some letters",
+	Long: "Find the words that can be formed with all or some of the letters,\n" +
+		"where a ? is a blank tile that can stand for any letter.\n\n" +
+		"The words are sorted by length, and then by their raw score, the sum\n" +
+		"of the points of the letters, where the blanks are worth zero points.",
+	Args: cobra.ExactArgs(1),
+	RunE: runAnagram,
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <pattern>",
+	Short: "Find the words that match a pattern",
+	Long: "Find the words that match a pattern, made of letters and of:\n\n" +
+		"  ?       any letter\n" +
+		"  *       any sequence of letters, including none\n" +
+		"  [ABC]   one of the letters A, B or C\n" +
+		"  [^ABC]  any letter but A, B or C\n\n" +
+		"The words are printed with the sum of the points of their letters.",
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func runAnagram(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	d, err := searchDistribution(cmd)
+	if err != nil {
+		return err
+	}
+	tiles, err := parseRack(args[0], d)
+	if err != nil {
+		return err
+	}
+	var wf wordFinder
+	if dp := cmd.Flag("dictionary").Value.String(); dp != "" {
+		wf, err = loadDictionaryFile(dp, d, 0)
+	} else {
+		wf, err = d.dictionary(0)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load dictionary: %s", err)
+	}
+	if wf == nil {
+		return fmt.Errorf("no dictionary available for distribution %s", d.name)
+	}
+	minLen := max(int(searchMinLen), minWordLen)
+	if anagramExact {
+		minLen = len(tiles)
+	}
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "WORD\tLENGTH\tPOINTS")
+	for _, a := range wf.findSubWords(tiles, d, minLen) {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\n", a.word, a.length, a.score)
+	}
+	return tw.Flush()
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	d, err := searchDistribution(cmd)
+	if err != nil {
+		return err
+	}
+	p, err := parsePattern(args[0], d)
+	if err != nil {
+		return err
+	}
+	p.minLen = int(searchMinLen)
+	p.maxLen = int(searchMaxLen)
+
+	var lex wordSet
+	if dp := cmd.Flag("dictionary").Value.String(); dp != "" {
+		lex, err = loadLexiconFile(dp, d)
+	} else {
+		lex, err = d.lexicon()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load lexicon: %s", err)
+	}
+	if lex == nil {
+		return fmt.Errorf("no dictionary available for distribution %s", d.name)
+	}
+	return writeMatches(cmd.OutOrStdout(), lex, p, d)
+}
+
+// writeMatches writes the words of the lexicon that
+// match the pattern, in alphabetical order.
+func writeMatches(w io.Writer, lex wordSet, p *pattern, d distribution) error {
+	var words [][]string
+
+	lex.match(p, func(letters []string) {
+		words = append(words, slices.Clone(letters))
+	})
+	sort.Slice(words, func(i, j int) bool {
+		return strings.Join(words[i], "") < strings.Join(words[j], "")
+	})
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "WORD\tLENGTH\tPOINTS")
+	for _, letters := range words {
+		points := uint(0)
+		for _, l := range letters {
+			points += d.points(l)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\n",
+			strings.Join(letters, ""),
+			len(letters),
+			points,
+		)
+	}
+	return tw.Flush()
+}
+
+func searchDistribution(cmd *cobra.Command) (distribution, error) {
+	dn := cmd.Flag("distribution").Value.String()
+
+	d, err := lookupDistribution(dn)
+	if err != nil {
+		return distribution{}, err
+	}
+	if searchMaxLen != 0 && searchMinLen > searchMaxLen {
+		return distribution{}, errors.New("minimum length cannot exceed maximum length")
+	}
+	policy, err := wordPolicyFlags(cmd)
+	if err != nil {
+		return distribution{}, err
+	}
+	d.policy = policy.apply(d.policy)
+
+	return d, nil
+}
+
+// parseRack returns the tiles of the letters, split
+// into the letters of the distribution, where a ? is
+// a blank tile.
+func parseRack(s string, d distribution) (rack, error) {
+	var (
+		tiles    rack
+		alphabet = d.alphabet()
+	)
+	for _, l := range d.tokenize(d.normalizer()(s)) {
+		if l != blank && !slices.Contains(alphabet, l) {
+			return nil, fmt.Errorf("unknown letter '%s' for distribution %s", l, d.name)
+		}
+		tiles = append(tiles, d.tile(letter{L: l, points: d.points(l)}))
+	}
+	if len(tiles) == 0 {
+		return nil, errors.New("no letters")
+	}
+	return tiles, nil
+}
+
+// patternElem is an element of a pattern, which matches
+// either a single letter, or any sequence of letters.
+type patternElem struct {
+	star    bool
+	letters []string // any letter if empty
+	negate  bool
+}
+
+func (e patternElem) matches(l string) bool {
+	if len(e.letters) == 0 {
+		return true
+	}
+	return slices.Contains(e.letters, l) != e.negate
+}
+
+// pattern matches words, given as sequences of letters,
+// that have between minLen and maxLen letters. A limit
+// of zero means no limit.
+type pattern struct {
+	elems  []patternElem
+	minLen int
+	maxLen int
+}
+
+// parsePattern parses the pattern, whose letters are
+// split into the letters of the distribution.
+func parsePattern(s string, d distribution) (*pattern, error) {
+	var (
+		p        pattern
+		alphabet = d.alphabet()
+		lit      strings.Builder
+	)
+	letters := func(s string) ([]string, error) {
+		ls := d.tokenize(s)
+		for _, l := range ls {
+			if !slices.Contains(alphabet, l) {
+				return nil, fmt.Errorf("unknown letter '%s' for distribution %s", l, d.name)
+			}
+		}
+		return ls, nil
+	}
+	flush := func() error {
+		ls, err := letters(lit.String())
+		if err != nil {
+			return err
+		}
+		for _, l := range ls {
+			p.elems = append(p.elems, patternElem{letters: []string{l}})
+		}
+		lit.Reset()
+
+		return nil
+	}
+	s = d.normalizer()(s)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '?' && c != '*' && c != '[' {
+			lit.WriteByte(c)
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		switch c {
+		case '?':
+			p.elems = append(p.elems, patternElem{})
+		case '*':
+			// Consecutive stars are equivalent to one.
+			if n := len(p.elems); n == 0 || !p.elems[n-1].star {
+				p.elems = append(p.elems, patternElem{star: true})
+			}
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, errors.New("unterminated letter class")
+			}
+			class := s[i+1 : i+end]
+			i += end
+
+			e := patternElem{}
+			if strings.HasPrefix(class, "^") {
+				e.negate = true
+				class = class[1:]
+			}
+			ls, err := letters(class)
+			if err != nil {
+				return nil, err
+			}
+			if len(ls) == 0 {
+				return nil, errors.New("empty letter class")
+			}
+			e.letters = ls
+			p.elems = append(p.elems, e)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(p.elems) == 0 {
+		return nil, errors.New("empty pattern")
+	}
+	if len(p.elems) > maxPatternLen {
+		return nil, fmt.Errorf("pattern too long (max %d elements)", maxPatternLen)
+	}
+	return &p, nil
+}
+
+// The states of a pattern are the set of the indices of
+// the elements that remain to be matched, stored in a
+// bitset. The state len(elems) means that the whole
+// pattern is matched.
+
+func (p *pattern) start() uint64 {
+	return p.closure(1)
+}
+
+// closure adds the states reachable by matching
+// a star element with an empty sequence.
+func (p *pattern) closure(s uint64) uint64 {
+	for i, e := range p.elems {
+		if e.star && s&(1<<i) != 0 {
+			s |= 1 << (i + 1)
+		}
+	}
+	return s
+}
+
+// step returns the states reached after matching the letter.
+func (p *pattern) step(s uint64, l string) uint64 {
+	var next uint64
+
+	for i, e := range p.elems {
+		if s&(1<<i) == 0 {
+			continue
+		}
+		if e.star {
+			next |= 1 << i
+		} else if e.matches(l) {
+			next |= 1 << (i + 1)
+		}
+	}
+	return p.closure(next)
+}
+
+func (p *pattern) accepts(s uint64, length int) bool {
+	if length < p.minLen || (p.maxLen != 0 && length > p.maxLen) {
+		return false
+	}
+	return s&(1<<len(p.elems)) != 0
+}
+
+// match returns whether the letters match the pattern.
+func (p *pattern) match(letters []string) bool {
+	if p.maxLen != 0 && len(letters) > p.maxLen {
+		return false
+	}
+	s := p.start()
+	for _, l := range letters {
+		if s = p.step(s, l); s == 0 {
+			return false
+		}
+	}
+	return p.accepts(s, len(letters))
+}
+
+// match visits the words of the lexicon that match the pattern.
+func (l lexicon) match(p *pattern, visit func(letters []string)) {
+	for _, w := range l {
+		if letters := splitLetters(w); p.match(letters) {
+			visit(letters)
+		}
+	}
+}
+
+// match visits the words of the graph that match the pattern.
+// The paths that cannot match the pattern are not followed.
+func (g *dawg) match(p *pattern, visit func(letters []string)) {
+	if len(g.edges) <= rootNode {
+		return
+	}
+	var (
+		word []string
+		walk func(node uint32, s uint64)
+	)
+	walk = func(node uint32, s uint64) {
+		for i := node; ; i++ {
+			e := g.edges[i]
+			l := g.alphabet[edgeLetter(e)]
+
+			if next := p.step(s, l); next != 0 {
+				word = append(word, l)
+				if e&edgeTerminal != 0 && p.accepts(next, len(word)) {
+					visit(word)
+				}
+				if c := edgeChild(e); c != 0 && (p.maxLen == 0 || len(word) < p.maxLen) {
+					walk(c, next)
+				}
+				word = word[:len(word)-1]
+			}
+			if e&edgeLast != 0 {
+				break
+			}
+		}
+	}
+	walk(rootNode, p.start())
+}
+
+func setupSearchFlags() {
+	for _, c := range []*cobra.Command{anagramCmd, searchCmd} {
+		f := c.Flags()
+		f.SortFlags = false
+
+		f.StringP("dictionary", "d", "",
+			"custom dictionary file path or built-in name",
+		)
+		f.StringP("distribution", "l", "",
+			"letter distribution language",
+		)
+		f.Uint8Var(&searchMinLen, "min-length", 0,
+			"minimum length of the words",
+		)
+	}
+	searchCmd.Flags().Uint8Var(&searchMaxLen, "max-length", 0,
+		"maximum length of the words",
+	)
+	anagramCmd.Flags().BoolVar(&anagramExact, "exact", false,
+		"only find the words formed with all the letters",
+	)
+}
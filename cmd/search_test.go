@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_pattern_match(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		word    string
+		minLen  int
+		maxLen  int
+		match   bool
+	}{
+		{"CAT", "CAT", 0, 0, true},
+		{"CAT", "CATS", 0, 0, false},
+		{"C?T", "CUT", 0, 0, true},
+		{"C?T", "CT", 0, 0, false},
+		{"C*", "C", 0, 0, true},
+		{"C*", "CATS", 0, 0, true},
+		{"*AT*", "SCATS", 0, 0, true},
+		{"*AT*", "TAS", 0, 0, false},
+		{"**S", "CATS", 0, 0, true},
+		{"[BC]AT", "BAT", 0, 0, true},
+		{"[BC]AT", "RAT", 0, 0, false},
+		{"[^BC]AT", "RAT", 0, 0, true},
+		{"[^BC]AT", "CAT", 0, 0, false},
+		{"C*", "CATS", 2, 3, false},
+		{"C*", "CAT", 2, 3, true},
+		{"c?t", "CAT", 0, 0, true},
+	} {
+		p, err := parsePattern(tt.pattern, english)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.minLen = tt.minLen
+		p.maxLen = tt.maxLen
+
+		if got := p.match(english.tokenize(tt.word)); got != tt.match {
+			t.Errorf("%s: got match %t for %s, want %t", tt.pattern, got, tt.word, tt.match)
+		}
+	}
+}
+
+func Test_parsePattern_digraphs(t *testing.T) {
+	p, err := parsePattern("CH?[LL]", spanish)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []patternElem{
+		{letters: []string{"CH"}},
+		{},
+		{letters: []string{"LL"}},
+	}
+	if !reflect.DeepEqual(p.elems, want) {
+		t.Errorf("got elements %v, want %v", p.elems, want)
+	}
+}
+
+func Test_parsePattern_errors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"CA[T",
+		"CA[]",
+		"CA1",
+		strings.Repeat("?", maxPatternLen+1),
+	} {
+		if _, err := parsePattern(s, english); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func Test_wordSet_match(t *testing.T) {
+	words := "cat\nact\nat\ncats\nscat\ncast\nzoo\n"
+
+	lex, err := parseLexicon(strings.NewReader(words), english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := compileDictionary(io.NopCloser(strings.NewReader(words)), english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		pattern string
+		maxLen  int
+		output  string
+	}{
+		{
+			"*AT*",
+			0,
+			"WORD   LENGTH   POINTS\n" +
+				"AT     2        2\n" +
+				"CAT    3        5\n" +
+				"CATS   4        6\n" +
+				"SCAT   4        6\n",
+		},
+		{
+			"?A*",
+			3,
+			"WORD   LENGTH   POINTS\n" +
+				"CAT    3        5\n",
+		},
+	} {
+		p, err := parsePattern(tt.pattern, english)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.maxLen = tt.maxLen
+
+		for _, ws := range []wordSet{lex, g} {
+			var buf bytes.Buffer
+			if err := writeMatches(&buf, ws, p, english); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.output {
+				t.Errorf("%s (%T): got output\n%s\nwant\n%s", tt.pattern, ws, got, tt.output)
+			}
+		}
+	}
+}
+
+func Test_parseRack(t *testing.T) {
+	tiles, err := parseRack("ch?o", spanish)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tiles.String(), "CH ? O"; got != want {
+		t.Errorf("got rack %s, want %s", got, want)
+	}
+	if _, err := parseRack("CAT1", english); err == nil {
+		t.Error("expected an error")
+	}
+}
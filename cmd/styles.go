@@ -17,5 +17,13 @@ var (
 	italicText   = lipgloss.NewStyle().Italic(true)
 	faintText    = lipgloss.NewStyle().Faint(true)
 	scrabbleList = lipgloss.NewStyle().Faint(true).Italic(true)
+	selectedWord = lipgloss.NewStyle().Bold(true).Underline(true)
 	alertText    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+
+	premiumStyles = map[premium]lipgloss.Style{
+		premiumDoubleLetter: lipgloss.NewStyle().Foreground(lipgloss.Color("#8FD3FE")),
+		premiumTripleLetter: lipgloss.NewStyle().Foreground(lipgloss.Color("#3A7BD5")),
+		premiumDoubleWord:   lipgloss.NewStyle().Foreground(lipgloss.Color("#F7A8B8")),
+		premiumTripleWord:   lipgloss.NewStyle().Foreground(lipgloss.Color("#E84A5F")),
+	}
 )
@@ -21,16 +21,23 @@ const (
 )
 
 // tile represents a Scrabble tile.
+// Its kind is determined by the distribution
+// at creation, see distribution.tile.
 type tile struct {
 	letter
+	vowel bool
 	inuse bool
 }
 
 type rack []tile
 
+// tiles represents a set of tiles split by kind. The
+// random number generator is used to shuffle and draw
+// tiles, so that a game can be reproduced.
 type tiles struct {
 	vowels     rack
 	consonants rack
+	rng        *rand.Rand
 }
 
 func (k letterKind) String() string {
@@ -46,13 +53,22 @@ func (k letterKind) String() string {
 
 // kind returns the kind of the tile's letter.
 func (t tile) kind() letterKind {
+	if t.vowel {
+		return kindVowel
+	}
+	return kindConsonant
+}
+
+// latinKind returns the kind of a letter based
+// on its Latin base character, without diacritics.
+func latinKind(l string) letterKind {
 	tr := transform.Chain(
 		norm.NFD,                           // decompose
 		runes.Remove(runes.In(unicode.Mn)), // remove diacritics
 		norm.NFC,                           // recompose
 		cases.Upper(language.Und),          // uppercase
 	)
-	s, _, _ := transform.String(tr, t.L)
+	s, _, _ := transform.String(tr, l)
 
 	switch s {
 	case "A", "E", "I", "O", "U", "Y":
@@ -92,8 +108,8 @@ func (r *rack) fill(t tile, n uint) {
 }
 
 // shuffle randomizes the order of the tiles.
-func (r *rack) shuffle() {
-	rand.Shuffle(len(*r), func(i, j int) {
+func (r *rack) shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(*r), func(i, j int) {
 		(*r)[i], (*r)[j] = (*r)[j], (*r)[i]
 	})
 }
@@ -130,7 +146,8 @@ func (r rack) splitByKind() (vowels, consonants rack) {
 	return
 }
 
-func (r rack) view(withPoints bool) string {
+// tileViews returns the rendering of each tile.
+func (r rack) tileViews(withPoints bool) []string {
 	strs := make([]string, 0, len(r))
 
 	for _, t := range r {
@@ -143,9 +160,15 @@ func (r rack) view(withPoints bool) string {
 		if withPoints {
 			style = style.Align(lipgloss.Right)
 		}
-		strs = append(strs, style.Render(t.view(withPoints)))
+		// Widen the tile for digraphs that
+		// don't fit in the default width.
+		v := t.view(withPoints)
+		if w := lipgloss.Width(v); w > style.GetWidth() {
+			style = style.Copy().Width(w)
+		}
+		strs = append(strs, style.Render(v))
 	}
-	return lipgloss.JoinHorizontal(lipgloss.Top, strs...)
+	return strs
 }
 
 func (s tiles) String() string {
@@ -169,19 +192,34 @@ func (s *tiles) tiles() rack {
 }
 
 func (s *tiles) shuffle() *tiles {
-	s.vowels.shuffle()
-	s.consonants.shuffle()
+	s.vowels.shuffle(s.rng)
+	s.consonants.shuffle(s.rng)
 
 	return s
 }
 
-func (s tiles) view(withPoints bool) string {
+// view renders the vowels followed by the consonants,
+// on as many rows as needed to fit in the given width.
+func (s tiles) view(withPoints bool, maxWidth int) string {
 	sb := strings.Builder{}
 
-	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
-		s.vowels.view(withPoints),
-		s.consonants.view(withPoints),
-	))
+	var (
+		rows  []string
+		row   []string
+		width int
+	)
+	for _, v := range append(s.vowels.tileViews(withPoints), s.consonants.tileViews(withPoints)...) {
+		w := lipgloss.Width(v)
+		if len(row) != 0 && width+w > maxWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, width = nil, 0
+		}
+		row = append(row, v)
+		width += w
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+
+	sb.WriteString(lipgloss.JoinVertical(lipgloss.Center, rows...))
 	sb.WriteByte('\n')
 
 	return sb.String()
@@ -189,7 +227,7 @@ func (s tiles) view(withPoints bool) string {
 
 func (s *tiles) drawRandom(n int, predicates []drawPredicate) rack {
 	n = min(n, s.length())
-	if n == 0 {
+	if n <= 0 {
 		return nil
 	}
 	draw := make(rack, 0, n)
@@ -199,11 +237,11 @@ L:
 		if s.length() == 0 {
 			return draw
 		}
-		s.vowels.shuffle()
-		s.consonants.shuffle()
+		s.vowels.shuffle(s.rng)
+		s.consonants.shuffle(s.rng)
 
 		// Pick a random tile spanning both slices.
-		idx := rand.Intn(s.length())
+		idx := s.rng.Intn(s.length())
 
 		var ts *rack
 		if idx < len(s.vowels) {
@@ -237,7 +275,7 @@ func (s *tiles) drawByKind(kind letterKind, n int, predicates []drawPredicate) r
 		ts = &s.vowels
 	}
 	n = min(n, len(*ts))
-	if n == 0 {
+	if n <= 0 {
 		return nil
 	}
 	draw := make(rack, 0, n)
@@ -247,9 +285,9 @@ L:
 		if len(*ts) == 0 {
 			return draw
 		}
-		ts.shuffle()
+		ts.shuffle(s.rng)
 
-		idx := rand.Intn(len(*ts))
+		idx := s.rng.Intn(len(*ts))
 
 		if j < maxPredicateRetries {
 			for _, p := range predicates {
@@ -1,10 +1,14 @@
 package cmd
 
-import "testing"
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
 
 func Test_tiles_drawByKind(t *testing.T) {
 	const dc = 5
-	b := newBag(french)
+	b := newBag(french, rand.New(rand.NewSource(1)))
 
 	for _, tt := range []struct {
 		name     string
@@ -46,7 +50,7 @@ func Test_tiles_drawByKind(t *testing.T) {
 }
 
 func Test_tiles_shuffle(t *testing.T) {
-	b := newBag(french)
+	b := newBag(french, rand.New(rand.NewSource(1)))
 
 	// A new tile collection is shuffled at creation.
 	// Snapshot the tiles order and compare it with another
@@ -70,7 +74,7 @@ func Test_tiles_shuffle(t *testing.T) {
 }
 
 func Test_tiles_drawByKind_full(t *testing.T) {
-	b := newBag(french)
+	b := newBag(french, rand.New(rand.NewSource(1)))
 
 	for !b.isEmpty() {
 		v := b.drawByKind(kindVowel, 3, nil)
@@ -117,3 +121,31 @@ func Test_rack_splitByKind(t *testing.T) {
 		}
 	}
 }
+
+func Test_tiles_view(t *testing.T) {
+	draw := tiles{}
+	for _, tl := range tilesFromWord("ABCDEFGHIJKLMNO", english) {
+		if tl.kind() == kindVowel {
+			draw.vowels.add(tl)
+		} else {
+			draw.consonants.add(tl)
+		}
+	}
+	// Each tile is five columns wide, and three
+	// lines high, followed by a newline.
+	for _, tt := range []struct {
+		width int
+		rows  int
+	}{
+		{width: 80, rows: 1},
+		{width: 75, rows: 1},
+		{width: 74, rows: 2},
+		{width: 20, rows: 4},
+		{width: 1, rows: 15},
+	} {
+		v := draw.view(false, tt.width)
+		if got := strings.Count(v, "\n") / 3; got != tt.rows {
+			t.Errorf("width %d: got %d rows, want %d", tt.width, got, tt.rows)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -22,12 +23,15 @@ const (
 	lang state = iota
 	draw
 	play
+	scoring
 )
 
 type tui struct {
 	game     *game
+	rec      *record
 	state    state
 	input    textinput.Model
+	scoreIn  textinput.Model
 	confirm  confirm.Model
 	menu     gridmenu.Model
 	timer    timer.Model
@@ -35,16 +39,44 @@ type tui struct {
 	height   int
 	insights int
 	opts     options
+	// replayPos is the position of the next
+	// event to apply when replaying a game.
+	replayPos   int
+	replayEnded bool
+	// played is the word played during the round,
+	// whose event is applied once the scores of all
+	// the players are entered.
+	played string
+	scores []score
+	alert  string
+	// defs holds the definitions of the words, and
+	// selected is the scrabble whose definition is
+	// shown, if any.
+	defs     definitions
+	selected string
+	// err is the error that stopped the game,
+	// returned once the program has exited.
+	err error
 }
 
 type options struct {
-	dictPath      string
+	dicts         []dictSource
+	defsPath      string
+	policy        policyFlags
 	showPoints    bool
+	board         bool
 	wordLength    int
 	minVowels     int
 	minConsonants int
+	official      bool
 	timerDuration time.Duration
 	predicates    []drawPredicate
+	predicateArgs []string
+	players       []string
+	seed          int64
+	recordPath    string
+	replay        *record
+	resume        *record
 }
 
 var _ tea.Model = &tui{}
@@ -65,45 +97,146 @@ func newTUI(distribName string, width, height int, opts options) (*tui, error) {
 }
 
 func (ui *tui) initGame(dn string) error {
-	distrib, ok := distributions[dn]
-	if !ok {
-		return fmt.Errorf("unknown distribution: %s", dn)
+	distrib, err := lookupDistribution(dn)
+	if err != nil {
+		return err
 	}
-	var (
-		err  error
-		dict indexedDict
+	distrib.policy = ui.opts.policy.apply(distrib.policy)
+
+	n, err := drawLength(distrib,
+		ui.opts.wordLength,
+		ui.opts.minVowels,
+		ui.opts.minConsonants,
+		ui.opts.official,
 	)
-	if ui.opts.dictPath == "" {
-		dict, err = distrib.dictionary(ui.opts.wordLength)
+	if err != nil {
+		return err
+	}
+	ui.opts.wordLength = n
+
+	dicts, err := loadGameDicts(distrib, ui.opts.dicts, ui.opts.board)
+	if err != nil {
+		return err
+	}
+	if ui.opts.defsPath != "" {
+		ui.defs, err = loadDefinitionsFile(ui.opts.defsPath, distrib)
 		if err != nil {
-			return fmt.Errorf("failed to load dictionary: %s", err)
+			return fmt.Errorf("failed to read definitions file %q: %s", ui.opts.defsPath, err)
 		}
-	} else {
-		dict, err = loadDictionaryFile(ui.opts.dictPath, distrib.lang, ui.opts.wordLength)
-		if err != nil {
-			return fmt.Errorf("failed to read dictionary file %q: %s", ui.opts.dictPath, err)
+	}
+	ui.game = newGame(distrib, ui.opts.seed, ui.opts.wordLength)
+	ui.game.dicts = dicts
+	ui.game.minVowels = ui.opts.minVowels
+	ui.game.minConsonants = ui.opts.minConsonants
+	ui.game.official = ui.opts.official
+	ui.game.predicates = ui.opts.predicates
+
+	if len(ui.opts.players) != 0 {
+		ui.game.scoreboard = newScoreboard(ui.opts.players)
+	}
+	if ui.opts.board {
+		ui.game.board = newBoard(distrib, nil)
+	}
+	ui.game.useDict(0)
+
+	if ui.opts.resume != nil {
+		return ui.resumeGame()
+	}
+	if ui.opts.recordPath != "" {
+		ui.rec = &record{
+			Seed:          ui.opts.seed,
+			Distribution:  dn,
+			WordLength:    ui.opts.wordLength,
+			MinVowels:     ui.opts.minVowels,
+			MinConsonants: ui.opts.minConsonants,
+			Official:      ui.opts.official,
+			Board:         ui.opts.board,
+			Predicates:    ui.opts.predicateArgs,
+			Players:       ui.opts.players,
 		}
 	}
-	ui.game = &game{
-		bag:     newBag(distrib),
-		draw:    &tiles{},
-		distrib: distrib,
-		dict:    dict,
-		wordLen: ui.opts.wordLength,
+	if err := ui.do(eventStart, ""); err != nil {
+		return err
 	}
-	ui.game.drawTiles(
-		ui.opts.minVowels,
-		ui.opts.minConsonants,
-		ui.opts.predicates...,
-	)
-	log.Printf("Starting new game with %q distribution...\n", dn)
+	log.Printf("Starting new game with %q distribution and seed %d...\n", dn, ui.opts.seed)
 	log.Printf("Initial draw is: %s\n", ui.game.draw)
 
+	if ui.opts.replay != nil {
+		// Skip the start event, which was
+		// applied by the initial draw.
+		ui.replayPos = 1
+		if err := ui.forward(); err != nil {
+			return err
+		}
+	}
+
 	ui.state = draw
 
 	return nil
 }
 
+// loadGameDicts loads the dictionaries of the sources, or
+// the embedded dictionary of the distribution if there are
+// none. The lexicons are only loaded for the board.
+func loadGameDicts(d distribution, sources []dictSource, board bool) ([]gameDict, error) {
+	if len(sources) == 0 {
+		// The dictionary is indexed for all word lengths
+		// to find the words shorter than the draw.
+		dict, err := d.dictionary(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dictionary: %s", err)
+		}
+		gd := gameDict{name: "default", dict: dict, skipped: skippedWords(dict)}
+		if board {
+			if gd.lex, err = d.lexicon(); err != nil {
+				return nil, fmt.Errorf("failed to load lexicon: %s", err)
+			}
+			if gd.lex == nil {
+				return nil, fmt.Errorf("the board requires a dictionary")
+			}
+		}
+		return []gameDict{gd}, nil
+	}
+	dicts := make([]gameDict, 0, len(sources))
+
+	for _, src := range sources {
+		dict, err := loadDictionaryFile(src.path, d, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dictionary file %q: %s", src.path, err)
+		}
+		gd := gameDict{name: src.name, dict: dict, skipped: skippedWords(dict)}
+		if board {
+			if gd.lex, err = loadLexiconFile(src.path, d); err != nil {
+				return nil, fmt.Errorf("failed to load lexicon: %s", err)
+			}
+		}
+		dicts = append(dicts, gd)
+	}
+	return dicts, nil
+}
+
+// resumeGame applies all the events of the recorded
+// game, and continues to record the new ones. The game
+// resumes in the play view if the last draw has been
+// accepted.
+func (ui *tui) resumeGame() error {
+	ui.rec = ui.opts.resume
+
+	for i, e := range ui.rec.Events {
+		if err := ui.game.apply(e); err != nil {
+			return fmt.Errorf("cannot resume game: event #%d (%s): %s", i+1, e.Kind, err)
+		}
+	}
+	log.Printf("Resuming game with %q distribution and seed %d...\n", ui.rec.Distribution, ui.rec.Seed)
+	log.Printf("Current draw is: %s\n", ui.game.draw)
+
+	ui.state = draw
+	if ui.rec.Events[len(ui.rec.Events)-1].Kind == eventAccept {
+		ui.state = play
+	}
+	return nil
+}
+
 func (ui *tui) Init() tea.Cmd {
 	ui.input = textinput.New()
 	{
@@ -111,8 +244,26 @@ func (ui *tui) Init() tea.Cmd {
 		ui.input.Prompt = "Enter tiles played: "
 		ui.input.Placeholder = "word"
 		ui.input.Validate = func(w string) error {
+			// A move is only checked once entered,
+			// since its word is typed after its
+			// position and uses the board letters.
+			if ui.game.board != nil {
+				return nil
+			}
 			return ui.game.playWord(w, true)
 		}
+		if ui.opts.board {
+			ui.input.Prompt = "Enter move played: "
+			ui.input.Placeholder = "H8 WORD"
+		}
+	}
+	ui.scoreIn = textinput.New()
+	{
+		ui.scoreIn.CharLimit = 0
+		ui.scoreIn.Placeholder = "points"
+		if ui.opts.board {
+			ui.scoreIn.Placeholder = "points or move"
+		}
 	}
 	ui.menu = gridmenu.New(distribChoices(), 4, 7)
 	{
@@ -124,6 +275,12 @@ func (ui *tui) Init() tea.Cmd {
 	if ui.opts.timerDuration != 0 {
 		ui.timer = timer.NewWithInterval(ui.opts.timerDuration, time.Second)
 	}
+	if ui.state == play {
+		ui.input.Focus()
+		if ui.opts.timerDuration != 0 {
+			return ui.timer.Start()
+		}
+	}
 	return nil
 }
 
@@ -148,25 +305,56 @@ func (ui *tui) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return ui, tea.Quit
 		case tea.KeyCtrlR:
-			if ui.state == draw {
+			if ui.state == draw && ui.opts.replay == nil && ui.game.endReason() == "" {
 				ui.insights = 0
-				ui.game.resetDraw(true)
-				ui.game.drawTiles(ui.opts.minVowels, ui.opts.minConsonants, ui.opts.predicates...)
+				if err := ui.do(eventReset, ""); err != nil {
+					return ui.fail(err)
+				}
 			}
 			return ui, nil
 		case tea.KeyEnter:
+			if ui.state != lang && ui.game.endReason() != "" {
+				return ui, nil
+			}
 			switch ui.state {
 			case lang:
+				// The draw settings may not suit the
+				// variant, and another can be chosen.
 				if err := ui.initGame(ui.menu.Selection()); err != nil {
-					return nil, tea.Quit
+					if ui.game != nil {
+						return ui.fail(err)
+					}
+					ui.alert = err.Error()
+					return ui, nil
 				}
+				ui.alert = ""
 				ui.state = draw
 				return ui, nil
 			case draw:
+				if ui.opts.replay != nil {
+					if ui.replayPos >= len(ui.opts.replay.Events) {
+						ui.replayEnded = true
+					}
+					if ui.replayEnded {
+						return ui, nil
+					}
+					// Show the word played in the recorded
+					// game, which can't be modified.
+					ui.input.SetValue(ui.opts.replay.Events[ui.replayPos].Word)
+					ui.input.Focus()
+					ui.state = play
+					if ui.opts.timerDuration != 0 {
+						return ui, ui.timer.Start()
+					}
+					return ui, nil
+				}
 				ok := ui.confirm.Value()
 				if ok {
 					log.Println("draw accepted")
 
+					if err := ui.do(eventAccept, ""); err != nil {
+						return ui.fail(err)
+					}
 					ui.input.Focus()
 					ui.state = play
 					if ui.opts.timerDuration != 0 {
@@ -174,8 +362,9 @@ func (ui *tui) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				} else {
 					ui.insights = 0
-					ui.game.drawTiles(ui.opts.minVowels, ui.opts.minConsonants, ui.opts.predicates...)
-
+					if err := ui.do(eventReject, ""); err != nil {
+						return ui.fail(err)
+					}
 					log.Printf("draw rejected, new draw: %s\n", ui.game.draw)
 				}
 				return ui, nil
@@ -184,32 +373,80 @@ func (ui *tui) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(word) == 0 {
 					break
 				}
-				if err := ui.game.playWord(word, false); err != nil {
-					return nil, tea.Quit
+				if ui.opts.replay == nil {
+					if err := ui.game.playWord(word, true); err != nil {
+						ui.alert = err.Error()
+						return ui, nil
+					}
+					ui.alert = ""
 				}
-				log.Printf("word played: %s\n", word)
-				log.Printf("%d tiles left in the bag, %d remaining tiles from previous draw\n",
-					ui.game.bag.length(),
-					ui.game.draw.length(),
-				)
-				// Draw new tiles.
-				ui.game.drawTiles(ui.opts.minVowels, ui.opts.minConsonants, ui.opts.predicates...)
+				if ui.opts.replay != nil {
+					// The recorded play event is applied
+					// along with the following events.
+					if err := ui.forward(); err != nil {
+						return ui.fail(err)
+					}
+				} else if ui.game.scoreboard != nil {
+					// Enter the scores of the players
+					// before the tiles are played.
+					ui.played = word
+					ui.scores = nil
+					ui.input.Blur()
+					ui.promptScore()
+					ui.state = scoring
+					return ui, nil
+				} else {
+					// Play the word and draw new tiles.
+					if err := ui.do(eventPlay, word); err != nil {
+						return ui.fail(err)
+					}
+				}
+				ui.nextRound(word)
 
-				log.Printf("new draw: %s\n", ui.game.draw)
+				return ui, nil
+			case scoring:
+				player := ui.opts.players[len(ui.scores)]
 
-				ui.insights = 0
-				ui.state = draw
-				ui.input.Reset()
+				sc, err := ui.game.scorePlay(player, ui.scoreIn.Value())
+				if err != nil {
+					ui.alert = err.Error()
+					return ui, nil
+				}
+				ui.alert = ""
+				ui.scores = append(ui.scores, sc)
+
+				if len(ui.scores) < len(ui.opts.players) {
+					ui.promptScore()
+					return ui, nil
+				}
+				ui.scoreIn.Blur()
 
-				if ui.opts.timerDuration != 0 {
-					ui.timer.Stop()
-					ui.timer.Timeout = ui.opts.timerDuration
+				err = ui.apply(event{
+					Kind:   eventPlay,
+					Word:   ui.played,
+					Scores: ui.scores,
+				})
+				if err != nil {
+					return ui.fail(err)
 				}
+				ui.nextRound(ui.played)
+
 				return ui, nil
 			}
 		case tea.KeyCtrlG:
 			ui.insights++
 			return ui, nil
+		case tea.KeyUp, tea.KeyDown:
+			if ui.browsing() {
+				ui.selectScrabble(m.Type == tea.KeyDown)
+				return ui, nil
+			}
+		case tea.KeyCtrlD:
+			if ui.state != lang && len(ui.game.dicts) > 1 {
+				ui.game.useDict((ui.game.active + 1) % len(ui.game.dicts))
+				log.Printf("dictionary switched to %s\n", ui.game.dicts[ui.game.active].name)
+			}
+			return ui, nil
 		}
 	}
 	var cmd tea.Cmd
@@ -222,25 +459,168 @@ func (ui *tui) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		ui.confirm, cmd = ui.confirm.Update(msg)
 		return ui, cmd
 	case play:
+		if ui.opts.replay != nil {
+			return ui, nil
+		}
 		ui.input, cmd = ui.input.Update(msg)
 		return ui, cmd
+	case scoring:
+		ui.scoreIn, cmd = ui.scoreIn.Update(msg)
+		return ui, cmd
 	}
 	return ui, nil
 }
 
+// fail stops the program because of the error,
+// which is returned by runTUI once it has exited.
+func (ui *tui) fail(err error) (tea.Model, tea.Cmd) {
+	ui.err = err
+	log.Printf("game stopped: %s\n", err)
+
+	return ui, tea.Quit
+}
+
+// nextRound resets the view for the next round,
+// once the word has been played.
+func (ui *tui) nextRound(word string) {
+	log.Printf("word played: %s\n", word)
+	log.Printf("%d tiles left in the bag, %d remaining tiles from previous draw\n",
+		ui.game.bag.length(),
+		ui.game.draw.length(),
+	)
+	log.Printf("new draw: %s\n", ui.game.draw)
+
+	ui.insights = 0
+	ui.state = draw
+	ui.input.Reset()
+
+	if ui.opts.timerDuration != 0 {
+		ui.timer.Stop()
+		ui.timer.Timeout = ui.opts.timerDuration
+	}
+}
+
+// browsing returns whether the scrabbles of the draw
+// are listed, and their definitions can be browsed.
+func (ui tui) browsing() bool {
+	return ui.state != lang &&
+		ui.defs != nil &&
+		ui.insights >= 2 &&
+		len(ui.game.scrabbles) != 0
+}
+
+// selectScrabble selects the next or the previous
+// scrabble of the draw, to show its definition.
+func (ui *tui) selectScrabble(next bool) {
+	n := len(ui.game.scrabbles)
+	i := slices.Index(ui.game.scrabbles, ui.selected)
+
+	switch {
+	case i == -1 && next:
+		i = 0
+	case i == -1:
+		i = n - 1
+	case next:
+		i = (i + 1) % n
+	default:
+		i = (i - 1 + n) % n
+	}
+	ui.selected = ui.game.scrabbles[i]
+}
+
+// promptScore prepares the input for the
+// score of the next player of the round.
+func (ui *tui) promptScore() {
+	player := ui.opts.players[len(ui.scores)]
+
+	ui.scoreIn.Reset()
+	ui.scoreIn.Prompt = fmt.Sprintf("Play of %s: ", player)
+	ui.scoreIn.Focus()
+}
+
+// do performs the action represented by the event
+// kind on the game, and records it if necessary.
+func (ui *tui) do(kind eventKind, word string) error {
+	return ui.apply(event{
+		Kind: kind,
+		Word: word,
+	})
+}
+
+// apply performs the action represented by the
+// event on the game, and records it if necessary.
+func (ui *tui) apply(e event) error {
+	if err := ui.game.apply(e); err != nil {
+		return err
+	}
+	if ui.rec == nil {
+		return nil
+	}
+	ui.rec.add(ui.game, e)
+
+	if err := ui.rec.save(ui.opts.recordPath); err != nil {
+		log.Printf("failed to save record: %s\n", err)
+	}
+	return nil
+}
+
+// forward applies the events of the replayed
+// game up to the next accepted draw.
+func (ui *tui) forward() error {
+	events := ui.opts.replay.Events
+
+	for ui.replayPos < len(events) {
+		e := events[ui.replayPos]
+		ui.replayPos++
+
+		if e.Kind == eventAccept {
+			return nil
+		}
+		ui.insights = 0
+		if err := ui.apply(e); err != nil {
+			return err
+		}
+	}
+	ui.replayEnded = true
+
+	return nil
+}
+
 func (ui tui) View() string {
 	var s string
 
 	if ui.state == lang {
 		s += lipgloss.NewStyle().Bold(true).Render("Choose a language")
-		s += strings.Repeat("\n", 3)
+		s += "\n\n" + faintText.Render(insightsMark+" word insights available")
+		s += strings.Repeat("\n", 2)
 		s += ui.menu.View()
+
+		if ui.alert != "" {
+			s += "\n\n" + alertText.Render(ui.alert)
+		}
 	} else {
-		if ui.game.bag.isEmpty() && ui.game.draw.isEmpty() {
+		if r := ui.game.endReason(); r != "" {
 			s = boldText.Render("Game finished")
+			s += "\n\n" + faintText.Render(r)
+		} else if ui.replayEnded {
+			s = boldText.Render("Replay finished")
 		} else {
 			s = ui.runningView()
 		}
+		if ui.game.scoreboard != nil {
+			s = lipgloss.JoinHorizontal(lipgloss.Center,
+				s,
+				strings.Repeat(" ", 6),
+				ui.game.scoreboard.view(),
+			)
+		}
+		if ui.game.board != nil {
+			s = lipgloss.JoinHorizontal(lipgloss.Center,
+				ui.game.board.view(),
+				strings.Repeat(" ", 6),
+				s,
+			)
+		}
 	}
 	return lipgloss.Place(
 		ui.width, ui.height,
@@ -258,13 +638,15 @@ func (ui tui) runningView() string {
 	)
 	sb.WriteString(strings.Repeat("\n", 2))
 
-	// Render the tiles of the draw.
-	sb.WriteString(ui.game.draw.view(ui.opts.showPoints))
+	// Render the tiles of the draw, next
+	// to the board and the scoreboard.
+	sb.WriteString(ui.game.draw.view(ui.opts.showPoints, ui.tilesWidth()))
 	sb.WriteByte('\n')
 
 	if ui.game.dict != nil {
-		for i, s := range ui.game.scrabbles {
-			ui.game.scrabbles[i] = strings.ToLower(s)
+		if s := ui.dictStatus(); s != "" {
+			sb.WriteString(faintText.Render(s))
+			sb.WriteByte('\n')
 		}
 		if ui.insights >= 1 {
 			if len(ui.game.scrabbles) == 0 {
@@ -281,22 +663,63 @@ func (ui tui) runningView() string {
 					width := ui.width / 3
 
 					sb.WriteByte('\n')
+					sel := -1
+					if ui.defs != nil {
+						sel = slices.Index(ui.game.scrabbles, ui.selected)
+					}
 					sb.WriteString(lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(
-						ui.wordListView(width),
+						wordListView(ui.wordsView(ui.game.scrabbles), width, sel),
 					))
+					if ui.defs != nil {
+						sb.WriteByte('\n')
+						sb.WriteString(ui.definitionView(sel, width))
+					}
+				}
+			}
+			if sub := ui.subWords(); len(sub) != 0 {
+				sb.WriteByte('\n')
+				sb.WriteString(fmt.Sprintf("found %d shorter words", len(sub)))
+
+				if ui.insights >= 2 {
+					sb.WriteString(strings.Repeat("\n", 2))
+					sb.WriteString(ui.anagramsView(sub, ui.width/3))
 				}
 			}
+			if ui.insights >= 3 {
+				sb.WriteString(strings.Repeat("\n", 2))
+				sb.WriteString(ui.extraWordsView(ui.width / 3))
+			}
+			if ui.game.top != nil {
+				sb.WriteByte('\n')
+				sb.WriteString(fmt.Sprintf("top: %s", ui.game.top))
+			}
+			if l := ui.missingLegend(); l != "" && ui.insights >= 2 {
+				sb.WriteString(strings.Repeat("\n", 2))
+				sb.WriteString(faintText.Render(l))
+			}
 		} else {
 			sb.WriteString(faintText.Render("(ctrl+g to show insight)"))
 		}
 		sb.WriteString(strings.Repeat("\n", 3))
+	} else {
+		sb.WriteString(faintText.Render("(no dictionary for insights, see --dictionary)"))
+		sb.WriteString(strings.Repeat("\n", 3))
 	}
 	switch ui.state {
 	case draw:
-		sb.WriteString(ui.confirm.View())
+		if ui.opts.replay != nil {
+			sb.WriteString(faintText.Render("(enter to show the word played)"))
+		} else {
+			sb.WriteString(ui.confirm.View())
+		}
 	case play:
 		sb.WriteString(ui.input.View())
 
+		if ui.alert != "" {
+			sb.WriteString(strings.Repeat("\n", 2))
+			sb.WriteString(alertText.Render(ui.alert))
+		}
+
 		if ui.state == play && ui.opts.timerDuration != 0 {
 			sb.WriteString(strings.Repeat("\n", 2))
 
@@ -306,11 +729,219 @@ func (ui tui) runningView() string {
 			}
 			sb.WriteString(ts)
 		}
+	case scoring:
+		sb.WriteString(fmt.Sprintf("Tiles played: %s", ui.played))
+		sb.WriteString(strings.Repeat("\n", 2))
+		sb.WriteString(ui.scoreIn.View())
+
+		if ui.alert != "" {
+			sb.WriteString(strings.Repeat("\n", 2))
+			sb.WriteString(alertText.Render(ui.alert))
+		}
 	}
 	return sb.String()
 }
 
-func (ui tui) wordListView(maxWidth int) string {
+// dictStatus returns the name of the active dictionary if
+// it can be switched, and the number of its words that were
+// skipped, if any.
+func (ui tui) dictStatus() string {
+	var (
+		parts []string
+		gd    = ui.game.dicts[ui.game.active]
+	)
+	if len(ui.game.dicts) > 1 {
+		parts = append(parts, gd.name+" dictionary")
+	}
+	if gd.skipped != 0 {
+		parts = append(parts, fmt.Sprintf("%d words skipped", gd.skipped))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	s := strings.Join(parts, ", ")
+	if len(ui.game.dicts) > 1 {
+		s += " (ctrl+d to switch)"
+	}
+	return s
+}
+
+// tilesWidth returns the width available to the tiles
+// of the draw, which are shown between the board and
+// the scoreboard, but no less than the width of the
+// insights when the terminal is too narrow.
+func (ui tui) tilesWidth() int {
+	w := ui.width
+	if ui.game.scoreboard != nil {
+		w -= lipgloss.Width(ui.game.scoreboard.view()) + 6
+	}
+	if ui.game.board != nil {
+		w -= lipgloss.Width(ui.game.board.view()) + 6
+	}
+	return max(w, ui.width/3)
+}
+
+// subWords returns the words that can be formed
+// with some of the tiles of the draw, but not all.
+func (ui tui) subWords() []anagram {
+	n := ui.game.draw.length()
+
+	for i, a := range ui.game.anagrams {
+		if a.length < n {
+			return ui.game.anagrams[i:]
+		}
+	}
+	return nil
+}
+
+// extraWordsView renders the scrabbles that can be formed
+// with all the tiles of the draw plus a letter on the board,
+// grouped by extra letter.
+func (ui tui) extraWordsView(maxWidth int) string {
+	n := ui.game.draw.length() + 1
+
+	if len(ui.game.extras) == 0 {
+		return italicText.Render(fmt.Sprintf("no scrabble on %d found", n))
+	}
+	count := 0
+	for _, e := range ui.game.extras {
+		count += len(e.words)
+	}
+	var plural string
+	if count > 1 {
+		plural = "s"
+	}
+	lines := []string{
+		fmt.Sprintf("found %d scrabble%s on %d", count, plural, n),
+	}
+	for _, e := range ui.game.extras {
+		words := ui.wordsView(e.words)
+		prefix := faintText.Render(e.letter + " ")
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+			prefix,
+			wordListView(words, maxWidth-lipgloss.Width(prefix), -1),
+		))
+	}
+	return lipgloss.NewStyle().Width(maxWidth).Align(lipgloss.Center).Render(
+		lipgloss.JoinVertical(lipgloss.Center, lines...),
+	)
+}
+
+// anagramsView renders the words grouped by length,
+// with their raw score. Only the best words of each
+// group are shown, and the groups of the longest words,
+// so that the insights of long draws fit on the screen.
+func (ui tui) anagramsView(anagrams []anagram, maxWidth int) string {
+	const (
+		maxWords  = 10
+		maxGroups = 5
+	)
+	var (
+		groups []string
+		words  []string
+		shown  int
+		hidden int
+	)
+	flush := func(length, total int) {
+		if len(words) == 0 {
+			return
+		}
+		if len(groups) == maxGroups {
+			hidden += total
+			return
+		}
+		if total > shown {
+			words = append(words, fmt.Sprintf("+%d", total-shown))
+		}
+		groups = append(groups, lipgloss.JoinVertical(lipgloss.Center,
+			faintText.Render(fmt.Sprintf("%d letters", length)),
+			wordListView(words, maxWidth, -1),
+		))
+	}
+	total := 0
+	for i, a := range anagrams {
+		if i > 0 && a.length != anagrams[i-1].length {
+			flush(anagrams[i-1].length, total)
+			words, shown, total = nil, 0, 0
+		}
+		total++
+		if shown < maxWords {
+			words = append(words, fmt.Sprintf("%s %d", ui.wordView(a.word), a.score))
+			shown++
+		}
+	}
+	if len(anagrams) != 0 {
+		flush(anagrams[len(anagrams)-1].length, total)
+	}
+	if hidden != 0 {
+		groups = append(groups, faintText.Render(fmt.Sprintf("+%d shorter words", hidden)))
+	}
+	return lipgloss.NewStyle().Width(maxWidth).Align(lipgloss.Center).Render(
+		lipgloss.JoinVertical(lipgloss.Center, groups...),
+	)
+}
+
+// wordView renders a word found in the active dictionary,
+// marked if it is not valid in the other dictionaries.
+func (ui tui) wordView(w string) string {
+	s := strings.ToLower(w)
+	if ui.game.missing[w] {
+		s += "*"
+	}
+	return s
+}
+
+func (ui tui) wordsView(words []string) []string {
+	s := make([]string, len(words))
+	for i, w := range words {
+		s[i] = ui.wordView(w)
+	}
+	return s
+}
+
+// missingLegend returns the legend of the marked
+// words, or an empty string if there are none.
+func (ui tui) missingLegend() string {
+	marked := false
+	for _, m := range ui.game.missing {
+		marked = marked || m
+	}
+	if !marked {
+		return ""
+	}
+	var names []string
+	for i, d := range ui.game.dicts {
+		if i != ui.game.active {
+			names = append(names, d.name)
+		}
+	}
+	if len(names) == 1 {
+		return fmt.Sprintf("* not valid in %s", names[0])
+	}
+	return fmt.Sprintf("* not valid in all of %s", strings.Join(names, ", "))
+}
+
+// definitionView renders the definition of the selected
+// scrabble, or how to select one.
+func (ui tui) definitionView(sel, maxWidth int) string {
+	if sel == -1 {
+		return faintText.Render("(↑/↓ to show definitions)")
+	}
+	w := ui.game.scrabbles[sel]
+
+	def, ok := ui.defs[w]
+	if !ok {
+		return italicText.Render(fmt.Sprintf("no definition found for %s", strings.ToLower(w)))
+	}
+	return lipgloss.NewStyle().Width(maxWidth).Align(lipgloss.Center).Render(
+		boldText.Render(strings.ToLower(w)) + " " + def,
+	)
+}
+
+// wordListView renders the words on as many lines as
+// needed to fit the width. The word at the selected index
+// is highlighted, unless the index is -1.
+func wordListView(words []string, maxWidth, selected int) string {
 	const wordSep = " ■ "
 
 	var (
@@ -318,7 +949,7 @@ func (ui tui) wordListView(maxWidth int) string {
 		lineWidth int
 		builder   strings.Builder
 	)
-	for _, w := range ui.game.scrabbles {
+	for i, w := range words {
 		width := 0
 
 		// Compute the rendered width of the word
@@ -331,7 +962,7 @@ func (ui tui) wordListView(maxWidth int) string {
 		// If the length plus the current line width
 		// exceed the maximum width, wrap to a new line.
 		if maxWidth > 0 && lineWidth+width > maxWidth {
-			lines = append(lines, scrabbleList.Render(strings.Clone(builder.String())))
+			lines = append(lines, strings.Clone(builder.String()))
 			builder.Reset()
 			lineWidth = lipgloss.Width(w)
 		} else {
@@ -340,13 +971,17 @@ func (ui tui) wordListView(maxWidth int) string {
 		// After a line wrap, the buffer is empty and
 		// a new line shouldn't start with a separator.
 		if builder.Len() != 0 {
-			builder.WriteString(wordSep)
+			builder.WriteString(scrabbleList.Render(wordSep))
+		}
+		style := scrabbleList
+		if i == selected {
+			style = selectedWord
 		}
-		builder.WriteString(w)
+		builder.WriteString(style.Render(w))
 	}
 	// Flush the remaining buffer as the last line.
 	if builder.Len() > 0 {
-		lines = append(lines, scrabbleList.Render(strings.Clone(builder.String())))
+		lines = append(lines, strings.Clone(builder.String()))
 	}
 	return lipgloss.JoinVertical(lipgloss.Top, lines...)
 }
@@ -365,13 +1000,24 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", m, s)
 }
 
+// insightsMark marks the distributions of the
+// menu whose dictionary gives insights.
+const insightsMark = "✓"
+
 func distribChoices() []gridmenu.Choice {
 	c := make([]gridmenu.Choice, 0, len(distributions))
 
 	for k, v := range distributions {
+		if _, ok := distribFileErrors[k]; ok {
+			continue
+		}
+		desc := v.name
+		if v.hasDictionary() {
+			desc += " " + insightsMark
+		}
 		c = append(c, gridmenu.Choice{
 			Name:        k,
-			Description: v.name,
+			Description: desc,
 		})
 	}
 	sort.Slice(c, func(i, j int) bool {
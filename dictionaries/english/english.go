@@ -4,3 +4,6 @@ import _ "embed"
 
 //go:embed sowpods.txt.gz
 var SOWPODS []byte
+
+//go:embed twl06.txt.gz
+var TWL06 []byte
@@ -0,0 +1,8 @@
+//go:build !nolargedicts
+
+package german
+
+import _ "embed"
+
+//go:embed hippler.txt.gz
+var Hippler []byte
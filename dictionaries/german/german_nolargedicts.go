@@ -0,0 +1,6 @@
+//go:build nolargedicts
+
+package german
+
+// Hippler is not embedded with the nolargedicts build tag.
+var Hippler []byte
@@ -0,0 +1,8 @@
+//go:build !nolargedicts
+
+package italian
+
+import _ "embed"
+
+//go:embed listediparole.txt.gz
+var ListeDiParole []byte
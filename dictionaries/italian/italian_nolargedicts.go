@@ -0,0 +1,6 @@
+//go:build nolargedicts
+
+package italian
+
+// ListeDiParole is not embedded with the nolargedicts build tag.
+var ListeDiParole []byte
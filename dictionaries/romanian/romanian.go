@@ -0,0 +1,8 @@
+//go:build !nolargedicts
+
+package romanian
+
+import _ "embed"
+
+//go:embed toatecuvintele.txt.gz
+var ToateCuvintele []byte
@@ -0,0 +1,6 @@
+//go:build nolargedicts
+
+package romanian
+
+// ToateCuvintele is not embedded with the nolargedicts build tag.
+var ToateCuvintele []byte
@@ -3,14 +3,18 @@ module github.com/wI2L/scrabbler
 go 1.21.0
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/charmbracelet/bubbles v0.16.1
 	github.com/charmbracelet/bubbletea v0.24.2
 	github.com/charmbracelet/lipgloss v0.8.0
+	github.com/klauspost/compress v1.17.11
 	github.com/muesli/termenv v0.15.2
 	github.com/spf13/cobra v1.7.0
+	github.com/ulikunitz/xz v0.5.15
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9
 	golang.org/x/term v0.12.0
 	golang.org/x/text v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (